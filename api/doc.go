@@ -1,2 +1,14 @@
 // Package api contains Rewrite API schemas and route helpers.
+//
+// # Unconfirmed endpoints
+//
+// Some routes and payloads are not part of the published API reference and
+// are modeled on assumptions until the API confirms them:
+//
+//   - Messages: the send, get, list and POST /projects/:id/messages/:messageId/cancel
+//     routes, APIMessage, MessageStatus and the send body.
+//   - Payments: the balance, usage, transactions and invoices routes and
+//     APIBalance, APIUsage, APITransaction and APIInvoice. This includes
+//     amounts in minor units of the currency and the transaction and invoice
+//     status values.
 package api
@@ -0,0 +1,65 @@
+package api
+
+import "encoding/json"
+
+// projectUpdateFields is the encoded form of RESTPatchUpdateProjectBody.
+type projectUpdateFields struct {
+	Name            string           `json:"name,omitempty"`
+	SenderIDs       *[]string        `json:"senderIds,omitempty"`
+	DefaultSenderID *json.RawMessage `json:"defaultSenderId,omitempty"`
+}
+
+var jsonNull = json.RawMessage("null")
+
+// MarshalJSON omits unset fields and sends null for ClearDefaultSenderID.
+func (b RESTPatchUpdateProjectBody) MarshalJSON() ([]byte, error) {
+	var fields projectUpdateFields
+	fields.Name = b.Name
+	if b.SenderIDs != nil {
+		fields.SenderIDs = &b.SenderIDs
+	}
+	switch {
+	case b.ClearDefaultSenderID:
+		fields.DefaultSenderID = &jsonNull
+	case b.DefaultSenderID != nil:
+		value, err := json.Marshal(*b.DefaultSenderID)
+		if err != nil {
+			return nil, err
+		}
+		raw := json.RawMessage(value)
+		fields.DefaultSenderID = &raw
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON sets ClearDefaultSenderID when defaultSenderId is null.
+func (b *RESTPatchUpdateProjectBody) UnmarshalJSON(data []byte) error {
+	var fields struct {
+		Name            string          `json:"name"`
+		SenderIDs       json.RawMessage `json:"senderIds"`
+		DefaultSenderID json.RawMessage `json:"defaultSenderId"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*b = RESTPatchUpdateProjectBody{Name: fields.Name}
+	if fields.SenderIDs != nil {
+		if err := json.Unmarshal(fields.SenderIDs, &b.SenderIDs); err != nil {
+			return err
+		}
+		if b.SenderIDs == nil {
+			b.SenderIDs = []string{}
+		}
+	}
+	switch {
+	case fields.DefaultSenderID == nil:
+	case string(fields.DefaultSenderID) == "null":
+		b.ClearDefaultSenderID = true
+	default:
+		if err := json.Unmarshal(fields.DefaultSenderID, &b.DefaultSenderID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
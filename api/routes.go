@@ -17,6 +17,9 @@ var Routes = RouteRegistry{
 	Webhooks:  WebhookRoutes{},
 	Templates: TemplateRoutes{},
 	APIKeys:   APIKeyRoutes{},
+	Messages:  MessageRoutes{},
+	Projects:  ProjectRoutes{},
+	Payments:  PaymentRoutes{},
 }
 
 // RouteRegistry groups route builders by resource.
@@ -24,6 +27,9 @@ type RouteRegistry struct {
 	Webhooks  WebhookRoutes
 	Templates TemplateRoutes
 	APIKeys   APIKeyRoutes
+	Messages  MessageRoutes
+	Projects  ProjectRoutes
+	Payments  PaymentRoutes
 }
 
 // WebhookRoutes builds webhook endpoints.
@@ -35,6 +41,15 @@ type TemplateRoutes struct{}
 // APIKeyRoutes builds API key endpoints.
 type APIKeyRoutes struct{}
 
+// MessageRoutes builds message endpoints. The routes are unconfirmed; see the package docs.
+type MessageRoutes struct{}
+
+// ProjectRoutes builds project endpoints.
+type ProjectRoutes struct{}
+
+// PaymentRoutes builds payment endpoints. The routes are unconfirmed; see the package docs.
+type PaymentRoutes struct{}
+
 // List returns GET /projects/:id/webhooks with cursor query.
 func (WebhookRoutes) List(id string, options *RESTCursorOptions) string {
 	return fmt.Sprintf("/projects/%s/webhooks?%s", id, createCursorQuery(options))
@@ -100,6 +115,115 @@ func (APIKeyRoutes) Delete(id, apiKeyID string) string {
 	return fmt.Sprintf("/projects/%s/api-keys/%s", id, apiKeyID)
 }
 
+// Get returns GET /projects/:id/api-keys/:apiKeyId.
+func (APIKeyRoutes) Get(id, apiKeyID string) string {
+	return fmt.Sprintf("/projects/%s/api-keys/%s", id, apiKeyID)
+}
+
+// Update returns PATCH /projects/:id/api-keys/:apiKeyId.
+func (APIKeyRoutes) Update(id, apiKeyID string) string {
+	return fmt.Sprintf("/projects/%s/api-keys/%s", id, apiKeyID)
+}
+
+// List returns GET /projects/:id/messages with cursor query.
+func (MessageRoutes) List(id string, options *RESTCursorOptions) string {
+	return fmt.Sprintf("/projects/%s/messages?%s", id, createCursorQuery(options))
+}
+
+// Send returns POST /projects/:id/messages.
+func (MessageRoutes) Send(id string) string {
+	return fmt.Sprintf("/projects/%s/messages", id)
+}
+
+// Get returns GET /projects/:id/messages/:messageId.
+func (MessageRoutes) Get(id, messageID string) string {
+	return fmt.Sprintf("/projects/%s/messages/%s", id, messageID)
+}
+
+// Cancel returns POST /projects/:id/messages/:messageId/cancel.
+func (MessageRoutes) Cancel(id, messageID string) string {
+	return fmt.Sprintf("/projects/%s/messages/%s/cancel", id, messageID)
+}
+
+// List returns GET /projects with cursor query.
+func (ProjectRoutes) List(options *RESTCursorOptions) string {
+	return "/projects?" + createCursorQuery(options)
+}
+
+// Get returns GET /projects/:id.
+func (ProjectRoutes) Get(id string) string {
+	return fmt.Sprintf("/projects/%s", id)
+}
+
+// Update returns PATCH /projects/:id.
+func (ProjectRoutes) Update(id string) string {
+	return fmt.Sprintf("/projects/%s", id)
+}
+
+// Balance returns GET /projects/:id/payments/balance.
+func (PaymentRoutes) Balance(id string) string {
+	return fmt.Sprintf("/projects/%s/payments/balance", id)
+}
+
+// Usage returns GET /projects/:id/payments/usage with period query.
+func (PaymentRoutes) Usage(id string, options *RESTGetUsageQueryParams) string {
+	route := fmt.Sprintf("/projects/%s/payments/usage", id)
+	if options == nil {
+		return route
+	}
+
+	query := url.Values{}
+	if options.From != "" {
+		query.Set("from", options.From)
+	}
+	if options.To != "" {
+		query.Set("to", options.To)
+	}
+	if len(query) == 0 {
+		return route
+	}
+	return route + "?" + query.Encode()
+}
+
+// Transactions returns GET /projects/:id/payments/transactions with cursor query.
+func (PaymentRoutes) Transactions(id string, options *RESTCursorOptions) string {
+	return fmt.Sprintf("/projects/%s/payments/transactions?%s", id, createCursorQuery(options))
+}
+
+// Invoices returns GET /projects/:id/payments/invoices with cursor query.
+func (PaymentRoutes) Invoices(id string, options *RESTCursorOptions) string {
+	return fmt.Sprintf("/projects/%s/payments/invoices?%s", id, createCursorQuery(options))
+}
+
+// Invoice returns GET /projects/:id/payments/invoices/:invoiceId.
+func (PaymentRoutes) Invoice(id, invoiceID string) string {
+	return fmt.Sprintf("/projects/%s/payments/invoices/%s", id, invoiceID)
+}
+
+// routeParams maps a collection segment to the name of the ID segment that follows it.
+var routeParams = map[string]string{
+	"projects":  "id",
+	"webhooks":  "webhookId",
+	"templates": "templateId",
+	"api-keys":  "apiKeyId",
+	"messages":  "messageId",
+	"invoices":  "invoiceId",
+}
+
+// RouteTemplate replaces IDs in a route with named placeholders, e.g.
+// /projects/1/webhooks/2 becomes /projects/{id}/webhooks/{webhookId}.
+// The query string is dropped.
+func RouteTemplate(route string) string {
+	path, _, _ := strings.Cut(route, "?")
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if param, ok := routeParams[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = "{" + param + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
 func createCursorQuery(options *RESTCursorOptions) string {
 	limit := 15
 	if options != nil && options.Limit > 0 {
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// knownScopes lists every scope except the wildcard, in declaration order.
+var knownScopes = []APIKeyScope{
+	APIKeyScopeReadProject,
+	APIKeyScopeWriteProject,
+	APIKeyScopeReadAPIKeys,
+	APIKeyScopeReadTemplates,
+	APIKeyScopeWriteTemplate,
+	APIKeyScopeReadPayments,
+	APIKeyScopeReadWebhooks,
+	APIKeyScopeWriteWebhooks,
+}
+
+// KnownScopes returns every scope the SDK knows about, excluding the wildcard.
+func KnownScopes() []APIKeyScope {
+	return slices.Clone(knownScopes)
+}
+
+// ParseScope validates a scope string and returns its canonical constant.
+//
+// The API mixes the project: and projects: prefixes, so either spelling is
+// accepted and mapped to the one the API expects.
+func ParseScope(value string) (APIKeyScope, error) {
+	value = strings.TrimSpace(value)
+	if value == string(APIKeyScopeWildcard) {
+		return APIKeyScopeWildcard, nil
+	}
+
+	normalized := normalizeScope(value)
+	for _, scope := range knownScopes {
+		if normalizeScope(string(scope)) == normalized {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("Unknown API key scope %q", value)
+}
+
+// IsKnown reports whether the scope is the wildcard or a known scope, in either prefix spelling.
+func (s APIKeyScope) IsKnown() bool {
+	_, err := ParseScope(string(s))
+	return err == nil
+}
+
+func normalizeScope(value string) string {
+	if rest, ok := strings.CutPrefix(value, "projects:"); ok {
+		return "project:" + rest
+	}
+	return value
+}
+
+// Scopes is a set of API key permissions.
+type Scopes []APIKeyScope
+
+// ParseScopes parses and deduplicates scope strings. Unknown scopes are reported together.
+func ParseScopes(values ...string) (Scopes, error) {
+	var out Scopes
+	var unknown []string
+	for _, value := range values {
+		scope, err := ParseScope(value)
+		if err != nil {
+			unknown = append(unknown, fmt.Sprintf("%q", value))
+			continue
+		}
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	if len(unknown) > 0 {
+		return out, fmt.Errorf("Unknown API key scopes: %s", strings.Join(unknown, ", "))
+	}
+	return out, nil
+}
+
+// Validate reports scopes the SDK does not know about.
+func (s Scopes) Validate() error {
+	values := make([]string, len(s))
+	for i, scope := range s {
+		values[i] = string(scope)
+	}
+	_, err := ParseScopes(values...)
+	return err
+}
+
+// Expand replaces the wildcard with every known scope and canonicalizes prefixes.
+// Unknown scopes are kept as-is.
+func (s Scopes) Expand() Scopes {
+	var out Scopes
+	add := func(scope APIKeyScope) {
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	for _, scope := range s {
+		if scope == APIKeyScopeWildcard {
+			for _, known := range knownScopes {
+				add(known)
+			}
+			continue
+		}
+		if parsed, err := ParseScope(string(scope)); err == nil {
+			scope = parsed
+		}
+		add(scope)
+	}
+	return out
+}
+
+// Allows reports whether the set grants every required scope.
+func (s Scopes) Allows(required ...APIKeyScope) bool {
+	return len(s.Missing(required...)) == 0
+}
+
+// Missing returns the required scopes the set does not grant.
+func (s Scopes) Missing(required ...APIKeyScope) Scopes {
+	granted := s.Expand()
+	var missing Scopes
+	for _, scope := range required {
+		if scope == "" {
+			continue
+		}
+		if parsed, err := ParseScope(string(scope)); err == nil {
+			scope = parsed
+		}
+		if scope == APIKeyScopeWildcard {
+			if !slices.Contains(s, APIKeyScopeWildcard) {
+				missing = append(missing, scope)
+			}
+			continue
+		}
+		if !slices.Contains(granted, scope) && !slices.Contains(missing, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
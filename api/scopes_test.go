@@ -0,0 +1,41 @@
+package api
+
+import "testing"
+
+func TestParseScopesNormalizesPrefixes(t *testing.T) {
+	scopes, err := ParseScopes("project:webhooks:read", "projects:templates:read", "project:webhooks:read")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scopes) != 2 || scopes[0] != APIKeyScopeReadWebhooks || scopes[1] != APIKeyScopeReadTemplates {
+		t.Fatalf("unexpected scopes: %v", scopes)
+	}
+
+	if _, err := ParseScopes("project:read", "project:nope"); err == nil || err.Error() != `Unknown API key scopes: "project:nope"` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestScopesAllows(t *testing.T) {
+	granted := Scopes{APIKeyScopeReadTemplates, APIKeyScopeWriteTemplate}
+	if !granted.Allows(APIKeyScopeReadTemplates) {
+		t.Fatal("expected read templates to be allowed")
+	}
+	if granted.Allows(APIKeyScopeReadTemplates, APIKeyScopeReadWebhooks) {
+		t.Fatal("expected read webhooks to be denied")
+	}
+	if missing := granted.Missing(APIKeyScopeReadWebhooks); len(missing) != 1 || missing[0] != APIKeyScopeReadWebhooks {
+		t.Fatalf("unexpected missing scopes: %v", missing)
+	}
+
+	wildcard := Scopes{APIKeyScopeWildcard}
+	if !wildcard.Allows(KnownScopes()...) || !wildcard.Allows(APIKeyScopeWildcard) {
+		t.Fatal("expected the wildcard to allow every scope")
+	}
+	if granted.Allows(APIKeyScopeWildcard) {
+		t.Fatal("expected the wildcard to require the wildcard")
+	}
+	if len(wildcard.Expand()) != len(KnownScopes()) {
+		t.Fatalf("unexpected expansion: %v", wildcard.Expand())
+	}
+}
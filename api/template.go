@@ -0,0 +1,211 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// TemplateContentMaxLength is the assumed longest template content, in
+// characters. The API does not document it, so only ValidateStrict applies it.
+const TemplateContentMaxLength = 1600
+
+var (
+	// placeholderPattern matches {{name}}, allowing spaces inside the braces.
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+	// templateNamePattern is the assumed template name format applied by ValidateStrict.
+	templateNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+)
+
+// RenderedTemplate is the result of rendering a template locally.
+type RenderedTemplate struct {
+	// Content is the rendered text.
+	Content string
+	// Missing lists declared variables used in the content that had neither a
+	// value nor a fallback. They render as empty text.
+	Missing []string
+	// Undeclared lists placeholders with no matching declared variable. They are
+	// left in the content as written.
+	Undeclared []string
+	// Unused lists value keys that no placeholder in the content refers to.
+	Unused []string
+}
+
+// Render fills the template content with values, falling back to the declared fallbacks.
+// See RenderTemplate for the rules it assumes.
+func (t APITemplate) Render(values map[string]string) RenderedTemplate {
+	var content string
+	if t.Content != nil {
+		content = *t.Content
+	}
+	return RenderTemplate(content, t.Variables, values)
+}
+
+// RenderTemplate previews content as a template message.
+//
+// Only the {{name}} placeholder and the per-variable fallback come from the API
+// schema. The remaining rules are assumptions that have not been confirmed
+// against the server:
+//   - spaces inside the braces, as in {{ name }}, are allowed;
+//   - only declared variables are replaced, and undeclared placeholders are kept as written;
+//   - an empty value is treated like an absent one and uses the fallback;
+//   - values are inserted verbatim in a single pass, without escaping or
+//     expanding placeholders inside them;
+//   - text outside placeholders, including whitespace and line breaks, is kept as is.
+func RenderTemplate(content string, variables []APITemplateVariable, values map[string]string) RenderedTemplate {
+	declared := make(map[string]APITemplateVariable, len(variables))
+	for _, variable := range variables {
+		if _, ok := declared[variable.Name]; !ok {
+			declared[variable.Name] = variable
+		}
+	}
+
+	var out RenderedTemplate
+	used := make(map[string]bool)
+	out.Content = placeholderPattern.ReplaceAllStringFunc(content, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		used[name] = true
+
+		variable, ok := declared[name]
+		if !ok {
+			appendOnce(&out.Undeclared, name)
+			return match
+		}
+		if value := values[name]; value != "" {
+			return value
+		}
+		if variable.Fallback == "" {
+			appendOnce(&out.Missing, name)
+		}
+		return variable.Fallback
+	})
+
+	for name := range values {
+		if _, ok := declared[name]; !ok || !used[name] {
+			out.Unused = append(out.Unused, name)
+		}
+	}
+	slices.Sort(out.Unused)
+	return out
+}
+
+// TemplatePlaceholders returns the distinct placeholder names in content, in order of appearance.
+func TemplatePlaceholders(content string) []string {
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(content, -1) {
+		appendOnce(&names, match[1])
+	}
+	return names
+}
+
+func appendOnce(names *[]string, name string) {
+	if !slices.Contains(*names, name) {
+		*names = append(*names, name)
+	}
+}
+
+// Validate checks the body and returns nil when it is valid.
+//
+// Detailed maps field paths such as name, content or variables.0.name to a
+// message, like the validation errors returned by the API.
+//
+// It checks for a missing name or content, an unnamed or repeated variable, a
+// {{placeholder}} with no entry in Variables, which could never be filled (see
+// APITemplateVariable), and a variable the content never uses.
+//
+// The name format and the content length limit are not documented by the API,
+// so Validate leaves them to the server. ValidateStrict adds them.
+func (b RESTPostCreateTemplateBody) Validate() *APIValidationError {
+	return b.validate(false)
+}
+
+// ValidateStrict is Validate plus two unconfirmed rules: the name must match
+// [a-z0-9_]{1,64} and the content must be at most TemplateContentMaxLength
+// characters. Use it only if your account enforces them.
+func (b RESTPostCreateTemplateBody) ValidateStrict() *APIValidationError {
+	return b.validate(true)
+}
+
+func (b RESTPostCreateTemplateBody) validate(strict bool) *APIValidationError {
+	detailed := make(map[string]any)
+	switch {
+	case b.Name == "":
+		detailed["name"] = "Expected a template name"
+	case strict && !templateNamePattern.MatchString(b.Name):
+		detailed["name"] = "Expected 1-64 lowercase letters, digits or underscores"
+	}
+	if b.Content == "" {
+		detailed["content"] = "Expected template content"
+	}
+	validateTemplate(detailed, b.Content, b.Variables, true, strict)
+	return validationError(detailed)
+}
+
+// Validate checks the fields set on the body, with the same rules as
+// RESTPostCreateTemplateBody.Validate, and returns nil when they are valid.
+//
+// Placeholders and variables are only checked against each other when both
+// Content and Variables are set, since the other half lives on the server.
+func (b RESTPatchUpdateTemplateBody) Validate() *APIValidationError {
+	return b.validate(false)
+}
+
+// ValidateStrict is Validate plus the unconfirmed content length limit of
+// RESTPostCreateTemplateBody.ValidateStrict.
+func (b RESTPatchUpdateTemplateBody) ValidateStrict() *APIValidationError {
+	return b.validate(true)
+}
+
+func (b RESTPatchUpdateTemplateBody) validate(strict bool) *APIValidationError {
+	detailed := make(map[string]any)
+	validateTemplate(detailed, b.Content, b.Variables, b.Content != "" && b.Variables != nil, strict)
+	return validationError(detailed)
+}
+
+func validateTemplate(detailed map[string]any, content string, variables []APITemplateVariable, crossCheck, strict bool) {
+	add := func(field, message string) {
+		if _, ok := detailed[field]; !ok {
+			detailed[field] = message
+		}
+	}
+
+	if strict && len([]rune(content)) > TemplateContentMaxLength {
+		add("content", fmt.Sprintf("Expected at most %d characters", TemplateContentMaxLength))
+	}
+
+	placeholders := TemplatePlaceholders(content)
+	declared := make(map[string]bool, len(variables))
+	for i, variable := range variables {
+		field := fmt.Sprintf("variables.%d.name", i)
+		switch {
+		case variable.Name == "":
+			add(field, "Expected a variable name")
+		case declared[variable.Name]:
+			add(field, "Duplicate variable name")
+		case crossCheck && !slices.Contains(placeholders, variable.Name):
+			add(field, fmt.Sprintf("Variable {{%s}} is not used in the content", variable.Name))
+		}
+		declared[variable.Name] = true
+	}
+
+	if !crossCheck {
+		return
+	}
+	var undeclared []string
+	for _, name := range placeholders {
+		if !declared[name] {
+			undeclared = append(undeclared, "{{"+name+"}}")
+		}
+	}
+	if len(undeclared) > 0 {
+		add("content", fmt.Sprintf("Expected variables for %s", strings.Join(undeclared, ", ")))
+	}
+}
+
+func validationError(detailed map[string]any) *APIValidationError {
+	if len(detailed) == 0 {
+		return nil
+	}
+	return &APIValidationError{Message: "Validation failed", Detailed: detailed}
+}
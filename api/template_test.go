@@ -0,0 +1,97 @@
+package api
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	content := "Hi {{name}},\n your code is {{ code }}. {{unknown}} {{plan}}"
+	template := APITemplate{
+		Content: &content,
+		Variables: []APITemplateVariable{
+			{Name: "name", Fallback: "there"},
+			{Name: "code"},
+			{Name: "plan", Fallback: "free"},
+			{Name: "spare"},
+		},
+	}
+
+	rendered := template.Render(map[string]string{"code": "{{name}} & <b>", "name": "", "extra": "x", "unknown": "y"})
+	if want := "Hi there,\n your code is {{name}} & <b>. {{unknown}} free"; rendered.Content != want {
+		t.Fatalf("unexpected content: %q", rendered.Content)
+	}
+	if len(rendered.Missing) != 0 {
+		t.Fatalf("unexpected missing variables: %v", rendered.Missing)
+	}
+	if !slices.Equal(rendered.Undeclared, []string{"unknown"}) {
+		t.Fatalf("unexpected undeclared placeholders: %v", rendered.Undeclared)
+	}
+	if !slices.Equal(rendered.Unused, []string{"extra", "unknown"}) {
+		t.Fatalf("unexpected unused values: %v", rendered.Unused)
+	}
+
+	rendered = template.Render(nil)
+	if !slices.Equal(rendered.Missing, []string{"code"}) || rendered.Content != "Hi there,\n your code is . {{unknown}} free" {
+		t.Fatalf("unexpected render without values: %+v", rendered)
+	}
+}
+
+func TestTemplatePlaceholders(t *testing.T) {
+	got := TemplatePlaceholders("{{a}} {{ b }} {{a}} {{c d}} {{{e}}} {{}}")
+	if !slices.Equal(got, []string{"a", "b", "e"}) {
+		t.Fatalf("unexpected placeholders: %v", got)
+	}
+}
+
+func TestValidateTemplateBodies(t *testing.T) {
+	valid := RESTPostCreateTemplateBody{
+		Name:      "Welcome-SMS",
+		Content:   "Hi {{ name }}" + strings.Repeat(".", 2000),
+		Variables: []APITemplateVariable{{Name: "name"}},
+	}
+	if details := valid.Validate(); details != nil {
+		t.Fatalf("expected the unconfirmed rules to be left to the server: %+v", details)
+	}
+	strict := valid.ValidateStrict()
+	if strict == nil || strict.Detailed["name"] != "Expected 1-64 lowercase letters, digits or underscores" ||
+		strict.Detailed["content"] != "Expected at most 1600 characters" {
+		t.Fatalf("unexpected strict validation: %+v", strict)
+	}
+
+	invalid := RESTPostCreateTemplateBody{
+		Content:   "Hi {{name}}, your code is {{code}} {{plan}}",
+		Variables: []APITemplateVariable{{Name: "name"}, {Name: "name"}, {}, {Name: "unused"}},
+	}
+	details := invalid.Validate()
+	if details == nil {
+		t.Fatal("expected validation details")
+	}
+	want := map[string]string{
+		"name":             "Expected a template name",
+		"content":          "Expected variables for {{code}}, {{plan}}",
+		"variables.1.name": "Duplicate variable name",
+		"variables.2.name": "Expected a variable name",
+		"variables.3.name": "Variable {{unused}} is not used in the content",
+	}
+	if len(details.Detailed) != len(want) {
+		t.Fatalf("unexpected validation details: %#v", details.Detailed)
+	}
+	for field, message := range want {
+		if details.Detailed[field] != message {
+			t.Fatalf("unexpected %s message: %#v", field, details.Detailed[field])
+		}
+	}
+
+	undeclared := RESTPatchUpdateTemplateBody{Content: "Hi {{name}} {{code}}", Variables: []APITemplateVariable{{Name: "name"}}}
+	if details := undeclared.Validate(); details == nil || details.Detailed["content"] != "Expected variables for {{code}}" {
+		t.Fatalf("unexpected update validation: %+v", details)
+	}
+	if details := (RESTPatchUpdateTemplateBody{Content: strings.Repeat(".", 2000)}).ValidateStrict(); details == nil || details.Detailed["content"] == nil {
+		t.Fatalf("expected the strict length check on updates: %+v", details)
+	}
+	if details := (RESTPatchUpdateTemplateBody{Content: "Hi {{code}}"}).Validate(); details != nil {
+		t.Fatalf("expected content-only updates to skip the variable check: %+v", details)
+	}
+}
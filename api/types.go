@@ -215,3 +215,217 @@ type RESTPostCreateAPIKeyBody struct {
 
 // RESTDeleteAPIKeyData corresponds to DELETE /projects/:id/api-keys/:apiKeyId.
 type RESTDeleteAPIKeyData = APIResponse[any]
+
+// RESTGetAPIKeyData corresponds to GET /projects/:id/api-keys/:apiKeyId.
+type RESTGetAPIKeyData = APIResponse[APIAPIKey]
+
+// RESTPatchUpdateAPIKeyData corresponds to PATCH /projects/:id/api-keys/:apiKeyId.
+type RESTPatchUpdateAPIKeyData = APIResponse[any]
+
+// RESTPatchUpdateAPIKeyBody is the request body for API key updates.
+type RESTPatchUpdateAPIKeyBody struct {
+	Name   string        `json:"name,omitempty"`
+	Scopes []APIKeyScope `json:"scopes,omitempty"`
+}
+
+// APIMessage represents an SMS message sent through Rewrite. Its shape is unconfirmed; see the package docs.
+type APIMessage struct {
+	ID          Snowflake         `json:"id"`
+	To          string            `json:"to"`
+	Content     string            `json:"content"`
+	Template    *Snowflake        `json:"template"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Status      MessageStatus     `json:"status"`
+	ScheduledAt *string           `json:"scheduledAt"`
+	CreatedAt   string            `json:"createdAt"`
+}
+
+// APICreatedMessage represents the send-message response payload.
+type APICreatedMessage struct {
+	ID        Snowflake     `json:"id"`
+	Status    MessageStatus `json:"status"`
+	CreatedAt string        `json:"createdAt"`
+}
+
+// MessageStatus represents the delivery status of a message.
+type MessageStatus string
+
+const (
+	// MessageStatusQueued means the message is waiting to be sent.
+	MessageStatusQueued MessageStatus = "QUEUED"
+	// MessageStatusScheduled means the message will be sent at ScheduledAt.
+	MessageStatusScheduled MessageStatus = "SCHEDULED"
+	// MessageStatusDelivered means the carrier confirmed delivery.
+	MessageStatusDelivered MessageStatus = "DELIVERED"
+	// MessageStatusFailed means delivery failed.
+	MessageStatusFailed MessageStatus = "FAILED"
+	// MessageStatusCanceled means the message was canceled before sending.
+	MessageStatusCanceled MessageStatus = "CANCELED"
+)
+
+// RESTPostSendMessageData corresponds to POST /projects/:id/messages.
+type RESTPostSendMessageData = APIResponse[APICreatedMessage]
+
+// RESTPostSendMessageBody is the request body for sending a message.
+//
+// Either Content or Template must be set.
+type RESTPostSendMessageBody struct {
+	To          string                       `json:"to"`
+	Content     string                       `json:"content,omitempty"`
+	Template    *RESTPostSendMessageTemplate `json:"template,omitempty"`
+	ScheduledAt string                       `json:"scheduledAt,omitempty"`
+}
+
+// RESTPostSendMessageTemplate references a template by ID or unique name.
+type RESTPostSendMessageTemplate struct {
+	Identifier string            `json:"identifier"`
+	Variables  map[string]string `json:"variables,omitempty"`
+}
+
+// RESTGetMessageData corresponds to GET /projects/:id/messages/:messageId.
+type RESTGetMessageData = APIResponse[APIMessage]
+
+// RESTGetListMessagesData corresponds to GET /projects/:id/messages.
+type RESTGetListMessagesData = APIResponse[[]APIMessage]
+
+// RESTGetListMessagesQueryParams corresponds to message list query params.
+type RESTGetListMessagesQueryParams = RESTCursorOptions
+
+// RESTPostCancelMessageData corresponds to POST /projects/:id/messages/:messageId/cancel.
+type RESTPostCancelMessageData = APIResponse[any]
+
+// APIProject represents a Rewrite project.
+type APIProject struct {
+	ID              Snowflake `json:"id"`
+	Name            string    `json:"name"`
+	SenderIDs       []string  `json:"senderIds"`
+	DefaultSenderID *string   `json:"defaultSenderId"`
+	CreatedAt       string    `json:"createdAt"`
+}
+
+// RESTGetProjectData corresponds to GET /projects/:id.
+type RESTGetProjectData = APIResponse[APIProject]
+
+// RESTGetListProjectsData corresponds to GET /projects.
+type RESTGetListProjectsData = APIResponse[[]APIProject]
+
+// RESTGetListProjectsQueryParams corresponds to project list query params.
+type RESTGetListProjectsQueryParams = RESTCursorOptions
+
+// RESTPatchUpdateProjectData corresponds to PATCH /projects/:id.
+type RESTPatchUpdateProjectData = APIResponse[any]
+
+// RESTPatchUpdateProjectBody is the request body for project updates.
+//
+// A nil SenderIDs leaves the list unchanged, while an empty non-nil slice
+// clears it. Set ClearDefaultSenderID to send a null defaultSenderId.
+//
+// The body is encoded by its MarshalJSON method, so the fields carry no JSON
+// tags of their own.
+type RESTPatchUpdateProjectBody struct {
+	// Name is sent as name when not empty.
+	Name string `json:"-"`
+	// SenderIDs is sent as senderIds when not nil.
+	SenderIDs []string `json:"-"`
+	// DefaultSenderID is sent as defaultSenderId when not nil.
+	DefaultSenderID *string `json:"-"`
+	// ClearDefaultSenderID sends defaultSenderId as null and wins over DefaultSenderID.
+	ClearDefaultSenderID bool `json:"-"`
+}
+
+// APIBalance represents the prepaid balance of a project.
+//
+// Amount is assumed to be in minor units of Currency, e.g. cents. The payment
+// payloads are unconfirmed; see the package docs.
+type APIBalance struct {
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	Credits   int64  `json:"credits"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// APIUsage summarizes credit usage for a period.
+type APIUsage struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Messages int64  `json:"messages"`
+	Credits  int64  `json:"credits"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// APITransaction represents a balance movement.
+type APITransaction struct {
+	ID          Snowflake       `json:"id"`
+	Type        TransactionType `json:"type"`
+	Amount      int64           `json:"amount"`
+	Currency    string          `json:"currency"`
+	Credits     int64           `json:"credits"`
+	Description *string         `json:"description"`
+	Invoice     *Snowflake      `json:"invoice"`
+	CreatedAt   string          `json:"createdAt"`
+}
+
+// TransactionType represents the kind of balance movement.
+type TransactionType string
+
+const (
+	// TransactionTypeCredit adds funds, e.g. a top-up.
+	TransactionTypeCredit TransactionType = "CREDIT"
+	// TransactionTypeDebit consumes funds, e.g. sent messages.
+	TransactionTypeDebit TransactionType = "DEBIT"
+	// TransactionTypeRefund returns funds for failed or canceled messages.
+	TransactionTypeRefund TransactionType = "REFUND"
+)
+
+// APIInvoice represents a billing invoice.
+type APIInvoice struct {
+	ID          Snowflake     `json:"id"`
+	Number      string        `json:"number"`
+	Status      InvoiceStatus `json:"status"`
+	Amount      int64         `json:"amount"`
+	Currency    string        `json:"currency"`
+	PeriodStart string        `json:"periodStart"`
+	PeriodEnd   string        `json:"periodEnd"`
+	URL         *string       `json:"url"`
+	CreatedAt   string        `json:"createdAt"`
+}
+
+// InvoiceStatus represents the payment status of an invoice.
+type InvoiceStatus string
+
+const (
+	// InvoiceStatusOpen means the invoice awaits payment.
+	InvoiceStatusOpen InvoiceStatus = "OPEN"
+	// InvoiceStatusPaid means the invoice has been paid.
+	InvoiceStatusPaid InvoiceStatus = "PAID"
+	// InvoiceStatusVoid means the invoice was canceled.
+	InvoiceStatusVoid InvoiceStatus = "VOID"
+)
+
+// RESTGetUsageQueryParams selects the usage period. Empty values default to the current month.
+type RESTGetUsageQueryParams struct {
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// RESTGetBalanceData corresponds to GET /projects/:id/payments/balance.
+type RESTGetBalanceData = APIResponse[APIBalance]
+
+// RESTGetUsageData corresponds to GET /projects/:id/payments/usage.
+type RESTGetUsageData = APIResponse[APIUsage]
+
+// RESTGetListTransactionsData corresponds to GET /projects/:id/payments/transactions.
+type RESTGetListTransactionsData = APIResponse[[]APITransaction]
+
+// RESTGetListTransactionsQueryParams corresponds to transaction list query params.
+type RESTGetListTransactionsQueryParams = RESTCursorOptions
+
+// RESTGetListInvoicesData corresponds to GET /projects/:id/payments/invoices.
+type RESTGetListInvoicesData = APIResponse[[]APIInvoice]
+
+// RESTGetListInvoicesQueryParams corresponds to invoice list query params.
+type RESTGetListInvoicesQueryParams = RESTCursorOptions
+
+// RESTGetInvoiceData corresponds to GET /projects/:id/payments/invoices/:invoiceId.
+type RESTGetInvoiceData = APIResponse[APIInvoice]
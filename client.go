@@ -15,6 +15,9 @@ type Client struct {
 	// Secret is the resolved API secret used for authentication.
 	secret string
 
+	// base is shared by every resource client.
+	base resources.Base
+
 	// APIKeys exposes API key operations.
 	APIKeys *resources.APIKeys
 
@@ -23,6 +26,15 @@ type Client struct {
 
 	// Webhooks exposes webhook operations.
 	Webhooks *resources.Webhooks
+
+	// Messages exposes SMS message operations.
+	Messages *resources.Messages
+
+	// Projects exposes project operations.
+	Projects *resources.Projects
+
+	// Payments exposes billing operations.
+	Payments *resources.Payments
 }
 
 // Rewrite is an alias to Client for naming parity with the Node SDK.
@@ -34,6 +46,8 @@ type RewriteOptions struct {
 	Secret string
 	// Rest customizes the low-level REST client options.
 	Rest *rest.Options
+	// DefaultProject is used by resource calls that do not specify a project.
+	DefaultProject string
 }
 
 // New creates a new Rewrite client instance.
@@ -63,17 +77,54 @@ func New(options any) (*Client, error) {
 		return nil, err
 	}
 
+	base := resources.Base{Rest: restClient, DefaultProject: resolved.DefaultProject}
+
 	client := &Client{
 		Rest:      restClient,
 		secret:    resolved.Secret,
-		APIKeys:   &resources.APIKeys{Base: resources.Base{Rest: restClient}},
-		Templates: &resources.Templates{Base: resources.Base{Rest: restClient}},
-		Webhooks:  &resources.Webhooks{Base: resources.Base{Rest: restClient}},
+		base:      base,
+		APIKeys:   &resources.APIKeys{Base: base},
+		Templates: &resources.Templates{Base: base},
+		Webhooks:  &resources.Webhooks{Base: base},
+		Messages:  &resources.Messages{Base: base},
+		Projects:  &resources.Projects{Base: base},
+		Payments:  &resources.Payments{Base: base},
 	}
 
 	return client, nil
 }
 
+// Services groups the resource clients behind interfaces, so code that depends
+// on it can be tested with mocks such as those in the rewritemock package.
+type Services struct {
+	APIKeys   resources.APIKeysService
+	Templates resources.TemplatesService
+	Webhooks  resources.WebhooksService
+	Messages  resources.MessagesService
+	Projects  resources.ProjectsService
+	Payments  resources.PaymentsService
+}
+
+// Services returns the client's resource clients as interfaces.
+func (c *Client) Services() Services {
+	return Services{
+		APIKeys:   c.APIKeys,
+		Templates: c.Templates,
+		Webhooks:  c.Webhooks,
+		Messages:  c.Messages,
+		Projects:  c.Projects,
+		Payments:  c.Payments,
+	}
+}
+
+// Project returns resource clients bound to a project ID.
+//
+//	project := client.Project("123")
+//	templates, err := project.Templates.List(ctx, nil)
+func (c *Client) Project(id string) *ProjectScope {
+	return resources.NewProjectScope(c.base, id)
+}
+
 // NewRewrite is an alias for New.
 func NewRewrite(options any) (*Rewrite, error) {
 	return New(options)
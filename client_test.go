@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 )
@@ -147,3 +148,429 @@ func TestAPIKeysListReturnsHTTPError(t *testing.T) {
 		t.Fatalf("unexpected message: %s", httpErr.Message)
 	}
 }
+
+func TestMessagesSendWithTemplate(t *testing.T) {
+	var payload map[string]any
+
+	var idempotencyKey string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/p1/messages" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"9","status":"QUEUED","createdAt":"2026-02-19T20:01:09.000Z"}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	sent, err := client.Messages.Send(context.Background(), SendMessageOptions{
+		Project:        "p1",
+		IdempotencyKey: "welcome-ana",
+		RESTPostSendMessageBody: RESTPostSendMessageBody{
+			To: "+5511999999999",
+			Template: &RESTPostSendMessageTemplate{
+				Identifier: "welcome",
+				Variables:  map[string]string{"name": "Ana"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+	if sent.Data.ID != "9" || sent.Data.Status != MessageStatusQueued {
+		t.Fatalf("unexpected payload: %+v", sent)
+	}
+	if idempotencyKey != "welcome-ana" {
+		t.Fatalf("unexpected idempotency key: %q", idempotencyKey)
+	}
+
+	template, ok := payload["template"].(map[string]any)
+	if !ok || template["identifier"] != "welcome" {
+		t.Fatalf("unexpected template in body: %#v", payload["template"])
+	}
+	if _, ok := payload["content"]; ok {
+		t.Fatalf("did not expect content in body: %#v", payload["content"])
+	}
+}
+
+func TestMessagesCancelUsesCallerIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/projects/p1/messages/9/cancel" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"data":null}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	options := CancelMessageOptions{Project: "p1", IdempotencyKey: "cancel-9"}
+	for range 2 {
+		if _, err := client.Messages.Cancel(context.Background(), "9", options); err != nil {
+			t.Fatalf("unexpected cancel error: %v", err)
+		}
+	}
+	if len(keys) != 2 || keys[0] != "cancel-9" || keys[1] != "cancel-9" {
+		t.Fatalf("unexpected idempotency keys: %v", keys)
+	}
+}
+
+func TestMessagesSendRequiresContentOrTemplate(t *testing.T) {
+	client, err := New("rw")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	_, err = client.Messages.Send(context.Background(), SendMessageOptions{
+		Project:                 "p1",
+		RESTPostSendMessageBody: RESTPostSendMessageBody{To: "+5511999999999"},
+	})
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func TestFailedEnvelopeOn2xxReturnsHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":false,"code":"TEMPLATE_NOT_FOUND","message":"Unknown template"}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	_, err = client.Templates.GetData(context.Background(), "welcome", "p1")
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected HTTPError, got %v", err)
+	}
+	if httpErr.Status != http.StatusOK || httpErr.Code != "TEMPLATE_NOT_FOUND" || httpErr.Message != "Unknown template" {
+		t.Fatalf("unexpected error: %+v", httpErr)
+	}
+}
+
+func TestGetDataUnwrapsEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"7","name":"welcome","content":"Hi","variables":[],"createdAt":"2026-02-19T20:01:09.000Z"}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	template, err := client.Templates.GetData(context.Background(), "welcome", "p1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if template.ID != "7" || template.Name != "welcome" {
+		t.Fatalf("unexpected template: %+v", template)
+	}
+}
+
+func TestProjectScopeAndDefaultProject(t *testing.T) {
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"data":[],"cursor":{"persist":false}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{
+		Secret:         "rw_test",
+		DefaultProject: "default",
+		Rest:           &RESTOptions{BaseURL: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	ctx := context.Background()
+	project := client.Project("p1")
+	if _, err := project.Templates.List(ctx, nil); err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+	if err := project.Webhooks.Delete(ctx, "w1"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := client.Messages.List(ctx, "", nil); err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+
+	want := []string{"/v1/projects/p1/templates", "/v1/projects/p1/webhooks/w1", "/v1/projects/default/messages"}
+	if len(paths) != len(want) {
+		t.Fatalf("unexpected paths: %v", paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("unexpected paths: %v", paths)
+		}
+	}
+
+	_, err = project.APIKeys.Create(ctx, CreateAPIKeyOptions{Project: "p2"})
+	if err == nil || len(paths) != 3 {
+		t.Fatalf("expected a project mismatch error, got %v", err)
+	}
+
+	bare, err := New(RewriteOptions{Secret: "rw_test", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	if _, err := bare.Templates.List(ctx, "", nil); !errors.Is(err, ErrMissingProject) {
+		t.Fatalf("expected ErrMissingProject, got %v", err)
+	}
+}
+
+func TestProjectsGetUpdateAndList(t *testing.T) {
+	var requests []string
+	var payload map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"data":null}`))
+		case http.MethodGet:
+			if r.URL.Path == "/v1/projects" {
+				_, _ = w.Write([]byte(`{"ok":true,"data":[{"id":"p1","name":"One"}],"cursor":{"persist":false}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"p1","name":"One","senderIds":["ACME"],"defaultSenderId":"ACME"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw_test", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	ctx := context.Background()
+	project, err := client.Projects.GetData(ctx, "p1")
+	if err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if project.Name != "One" || len(project.SenderIDs) != 1 || project.DefaultSenderID == nil || *project.DefaultSenderID != "ACME" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	if _, err := client.Project("p1").Update(ctx, RESTPatchUpdateProjectBody{Name: "Renamed"}); err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+	if payload["name"] != "Renamed" || len(payload) != 1 {
+		t.Fatalf("unexpected payload: %#v", payload)
+	}
+
+	projects, err := client.Projects.List(ctx, nil)
+	if err != nil || len(projects.Data) != 1 {
+		t.Fatalf("unexpected list result: %+v, %v", projects, err)
+	}
+
+	want := []string{"GET /v1/projects/p1", "PATCH /v1/projects/p1", "GET /v1/projects?limit=15"}
+	for i := range want {
+		if i >= len(requests) || requests[i] != want[i] {
+			t.Fatalf("unexpected requests: %v", requests)
+		}
+	}
+}
+
+func TestPaymentsBalanceUsageAndInvoices(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/projects/p1/payments/balance":
+			_, _ = w.Write([]byte(`{"ok":true,"data":{"amount":1250,"currency":"BRL","credits":500}}`))
+		case "/v1/projects/p1/payments/usage":
+			_, _ = w.Write([]byte(`{"ok":true,"data":{"messages":42,"credits":42}}`))
+		case "/v1/projects/p1/payments/invoices":
+			if r.URL.Query().Get("after") == "" {
+				_, _ = w.Write([]byte(`{"ok":true,"data":[{"id":"i1","status":"PAID"}],"cursor":{"persist":true,"next":"i1"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"data":[{"id":"i2","status":"OPEN"}],"cursor":{"persist":false}}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw_test", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	ctx := context.Background()
+	payments := client.Project("p1").Payments
+
+	balance, err := Data(payments.Balance(ctx))
+	if err != nil || balance.Amount != 1250 || balance.Currency != "BRL" || balance.Credits != 500 {
+		t.Fatalf("unexpected balance: %+v, %v", balance, err)
+	}
+
+	usage, err := Data(payments.Usage(ctx, &RESTGetUsageQueryParams{From: "2026-01-01", To: "2026-01-31"}))
+	if err != nil || usage.Messages != 42 {
+		t.Fatalf("unexpected usage: %+v, %v", usage, err)
+	}
+
+	var invoices []APIInvoice
+	for invoice, err := range payments.AllInvoices(ctx, nil) {
+		if err != nil {
+			t.Fatalf("unexpected invoice error: %v", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+	if len(invoices) != 2 || invoices[1].Status != InvoiceStatusOpen {
+		t.Fatalf("unexpected invoices: %+v", invoices)
+	}
+
+	if requests[1] != "/v1/projects/p1/payments/usage?from=2026-01-01&to=2026-01-31" {
+		t.Fatalf("unexpected usage request: %s", requests[1])
+	}
+}
+
+func TestAPIKeysRotate(t *testing.T) {
+	var requests []string
+	var created map[string]any
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"old","name":"backend","scopes":["project:read","project:templates:read"]}}`))
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"new","key":"rw_new"}}`))
+		case http.MethodDelete:
+			_, _ = w.Write([]byte(`{"ok":true,"data":null}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{Secret: "rw_test", Rest: &RESTOptions{BaseURL: server.URL}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	keys := client.Project("p1").APIKeys
+
+	var activated string
+	key, err := keys.Rotate(context.Background(), "old", RotateAPIKeyOptions{
+		Activate: func(_ context.Context, key APICreatedAPIKey) error {
+			activated = key.Key
+			return nil
+		},
+	})
+	if err != nil || key.Key != "rw_new" || activated != "rw_new" {
+		t.Fatalf("unexpected rotate result: %+v, %v", key, err)
+	}
+	if created["name"] != "backend" || len(created["scopes"].([]any)) != 2 {
+		t.Fatalf("unexpected create payload: %#v", created)
+	}
+	want := []string{"GET /v1/projects/p1/api-keys/old", "POST /v1/projects/p1/api-keys", "DELETE /v1/projects/p1/api-keys/old"}
+	for i := range want {
+		if i >= len(requests) || requests[i] != want[i] {
+			t.Fatalf("unexpected requests: %v", requests)
+		}
+	}
+
+	requests = nil
+	activateErr := errors.New("deploy failed")
+	_, err = keys.Rotate(context.Background(), "old", RotateAPIKeyOptions{
+		Activate: func(context.Context, APICreatedAPIKey) error { return activateErr },
+	})
+
+	var rotationErr *RotationError
+	if !errors.As(err, &rotationErr) || rotationErr.Stage != RotationStageActivate || !errors.Is(err, activateErr) {
+		t.Fatalf("unexpected rotate error: %v", err)
+	}
+	if len(requests) != 3 || requests[2] != "DELETE /v1/projects/p1/api-keys/new" {
+		t.Fatalf("expected the replacement key to be rolled back: %v", requests)
+	}
+}
+
+func TestScopesFailFastAndMinimalScopes(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"data":[],"cursor":{"persist":false}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(RewriteOptions{
+		Secret: "rw_test",
+		Rest: &RESTOptions{
+			BaseURL: server.URL,
+			Scopes:  Scopes{APIKeyScopeReadTemplates},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Templates.List(ctx, "p1", nil); err != nil {
+		t.Fatalf("unexpected list error: %v", err)
+	}
+
+	err = client.Webhooks.Delete(ctx, "w1", "p1")
+	var scopeErr *ScopeError
+	if !errors.As(err, &scopeErr) || !errors.Is(err, ErrMissingScope) || scopeErr.Operation != "Webhooks.Delete" {
+		t.Fatalf("unexpected scope error: %v", err)
+	}
+	if len(scopeErr.Missing) != 1 || scopeErr.Missing[0] != APIKeyScopeWriteWebhooks {
+		t.Fatalf("unexpected missing scopes: %v", scopeErr.Missing)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the denied call to skip the network, got %d calls", calls)
+	}
+
+	if _, err := client.Messages.List(ctx, "p1", nil); err != nil {
+		t.Fatalf("expected an operation without a known scope to skip the check, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the unscoped call to reach the server, got %d calls", calls)
+	}
+
+	scopes, err := MinimalScopes("Templates.List", "Templates.Get", "Messages.Send", "Webhooks.Create")
+	if err != nil || len(scopes) != 2 || scopes[0] != APIKeyScopeReadTemplates || scopes[1] != APIKeyScopeWriteWebhooks {
+		t.Fatalf("unexpected minimal scopes: %v, %v", scopes, err)
+	}
+	if _, err := MinimalScopes("Templates.Nope"); err == nil {
+		t.Fatal("expected an unknown operation error")
+	}
+	if !slices.Contains(UnscopedOperations(), "APIKeys.Rotate") || slices.Contains(Operations(), "APIKeys.Rotate") {
+		t.Fatalf("expected APIKeys.Rotate to be unscoped, got %v", UnscopedOperations())
+	}
+}
@@ -5,6 +5,10 @@
 //   - APIKeys
 //   - Templates
 //   - Webhooks
+//   - Messages
+//   - Projects
+//   - Payments
 //
-// It also exposes a low-level REST client through Client.Rest.
+// It also exposes a low-level REST client through Client.Rest. Incoming webhook
+// deliveries can be verified and decoded with the webhooks subpackage.
 package rewrite
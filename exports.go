@@ -16,14 +16,98 @@ var (
 	Routes = api.Routes
 )
 
+// RequestInfoFromContext returns the RequestInfo stored on a middleware request context.
+var RequestInfoFromContext = rest.RequestInfoFromContext
+
+// NewIdempotencyKey returns a random key suitable for the IdempotencyKey options.
+var NewIdempotencyKey = rest.NewIdempotencyKey
+
+// DefaultShouldRetry is the retry classifier used when RetryOptions.ShouldRetry is nil.
+var DefaultShouldRetry = rest.DefaultShouldRetry
+
+// Retry reason constants.
+const (
+	RetryReasonStatus    = rest.RetryReasonStatus
+	RetryReasonTransport = rest.RetryReasonTransport
+)
+
+// Sentinel errors matched by HTTPError through errors.Is.
+var (
+	ErrUnauthorized = rest.ErrUnauthorized
+	ErrForbidden    = rest.ErrForbidden
+	ErrNotFound     = rest.ErrNotFound
+	ErrRateLimited  = rest.ErrRateLimited
+	ErrValidation   = rest.ErrValidation
+)
+
+// ErrMissingProject is returned when a call has no project ID and no DefaultProject is configured.
+var ErrMissingProject = resources.ErrMissingProject
+
+// API key rotation stages reported by RotationError.
+const (
+	RotationStageActivate = resources.RotationStageActivate
+	RotationStageRevoke   = resources.RotationStageRevoke
+)
+
+// ErrCircuitOpen matches CircuitOpenError through errors.Is.
+var ErrCircuitOpen = rest.ErrCircuitOpen
+
+// Circuit breaker constants.
+const (
+	CircuitClosed     = rest.CircuitClosed
+	CircuitOpen       = rest.CircuitOpen
+	CircuitHalfOpen   = rest.CircuitHalfOpen
+	CircuitScopeHost  = rest.CircuitScopeHost
+	CircuitScopeRoute = rest.CircuitScopeRoute
+)
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction matches a request.
+var ErrCassetteMiss = rest.ErrCassetteMiss
+
+// Cassette modes.
+const (
+	CassetteAuto   = rest.CassetteAuto
+	CassetteRecord = rest.CassetteRecord
+	CassetteReplay = rest.CassetteReplay
+)
+
+// ErrLimiterDeadline is returned when the client-side limiter wait would outlive the context deadline.
+var ErrLimiterDeadline = rest.ErrLimiterDeadline
+
+// Data unwraps the Data field of a resource call result.
+//
+//	template, err := rewrite.Data(client.Templates.Get(ctx, "welcome", projectId))
+func Data[T any](response api.APIResponse[T], err error) (T, error) {
+	return resources.Data(response, err)
+}
+
 // Low-level REST aliases.
 type (
-	RESTOptions          = rest.Options
-	RetryOptions         = rest.RetryOptions
-	FetchOptions         = rest.FetchOptions
-	RetryCallbackOptions = rest.HandleErrorOptions
-	RetryResponseMeta    = rest.ResponseMeta
-	HTTPError            = rest.HTTPError
+	RESTOptions           = rest.Options
+	RetryOptions          = rest.RetryOptions
+	LimiterOptions        = rest.LimiterOptions
+	CircuitBreakerOptions = rest.CircuitBreakerOptions
+	CircuitOpenError      = rest.CircuitOpenError
+	CircuitState          = rest.CircuitState
+	CircuitScope          = rest.CircuitScope
+	CassetteOptions       = rest.CassetteOptions
+	CassetteMode          = rest.CassetteMode
+	Cassette              = rest.Cassette
+	Interaction           = rest.Interaction
+	RecordedRequest       = rest.RecordedRequest
+	RecordedResponse      = rest.RecordedResponse
+	FetchOptions          = rest.FetchOptions
+	RetryCallbackOptions  = rest.HandleErrorOptions
+	RetryResponseMeta     = rest.ResponseMeta
+	RateLimit             = rest.RateLimit
+	RetryReason           = rest.RetryReason
+	RESTMiddleware        = rest.Middleware
+	RESTHandler           = rest.Handler
+	RequestInfo           = rest.RequestInfo
+	CallObserver          = rest.CallObserver
+	CallInfo              = rest.CallInfo
+	CallResult            = rest.CallResult
+	HTTPError             = rest.HTTPError
 )
 
 // Resource option aliases.
@@ -33,6 +117,23 @@ type (
 	UpdateTemplateOptions = resources.UpdateTemplateOptions
 	CreateWebhookOptions  = resources.CreateWebhookOptions
 	UpdateWebhookOptions  = resources.UpdateWebhookOptions
+	SendMessageOptions    = resources.SendMessageOptions
+	CancelMessageOptions  = resources.CancelMessageOptions
+	PaginateOptions       = resources.PaginateOptions
+	ProjectScope          = resources.ProjectScope
+	UpdateProjectOptions  = resources.UpdateProjectOptions
+	UpdateAPIKeyOptions   = resources.UpdateAPIKeyOptions
+	RotateAPIKeyOptions   = resources.RotateAPIKeyOptions
+	RotationError         = resources.RotationError
+	ValidationError       = resources.ValidationError
+	RotationStage         = resources.RotationStage
+	ScopeError            = rest.ScopeError
+	APIKeysService        = resources.APIKeysService
+	TemplatesService      = resources.TemplatesService
+	WebhooksService       = resources.WebhooksService
+	MessagesService       = resources.MessagesService
+	ProjectsService       = resources.ProjectsService
+	PaymentsService       = resources.PaymentsService
 )
 
 // API model aliases.
@@ -44,10 +145,22 @@ type (
 	APITemplate         = api.APITemplate
 	APICreatedTemplate  = api.APICreatedTemplate
 	APITemplateVariable = api.APITemplateVariable
+	RenderedTemplate    = api.RenderedTemplate
 	APIWebhook          = api.APIWebhook
 	APICreatedWebhook   = api.APICreatedWebhook
+	APIMessage          = api.APIMessage
+	APICreatedMessage   = api.APICreatedMessage
+	MessageStatus       = api.MessageStatus
+	APIProject          = api.APIProject
+	APIBalance          = api.APIBalance
+	APIUsage            = api.APIUsage
+	APITransaction      = api.APITransaction
+	TransactionType     = api.TransactionType
+	APIInvoice          = api.APIInvoice
+	InvoiceStatus       = api.InvoiceStatus
 	APIValidationError  = api.APIValidationError
 	APIKeyScope         = api.APIKeyScope
+	Scopes              = api.Scopes
 	WebhookEventType    = api.WebhookEventType
 	WebhookStatus       = api.WebhookStatus
 	RESTCursorOptions   = api.RESTCursorOptions
@@ -55,27 +168,47 @@ type (
 
 // API response/body aliases.
 type (
-	RESTGetWebhookData              = api.RESTGetWebhookData
-	RESTPostCreateWebhookData       = api.RESTPostCreateWebhookData
-	RESTPostCreateWebhookBody       = api.RESTPostCreateWebhookBody
-	RESTDeleteWebhookData           = api.RESTDeleteWebhookData
-	RESTPatchUpdateWebhookData      = api.RESTPatchUpdateWebhookData
-	RESTPatchUpdateWebhookBody      = api.RESTPatchUpdateWebhookBody
-	RESTGetListWebhooksData         = api.RESTGetListWebhooksData
-	RESTGetListWebhooksQueryParams  = api.RESTGetListWebhooksQueryParams
-	RESTGetListTemplatesData        = api.RESTGetListTemplatesData
-	RESTGetListTemplatesQueryParams = api.RESTGetListTemplatesQueryParams
-	RESTPostCreateTemplateData      = api.RESTPostCreateTemplateData
-	RESTPostCreateTemplateBody      = api.RESTPostCreateTemplateBody
-	RESTPatchUpdateTemplateData     = api.RESTPatchUpdateTemplateData
-	RESTPatchUpdateTemplateBody     = api.RESTPatchUpdateTemplateBody
-	RESTDeleteTemplateData          = api.RESTDeleteTemplateData
-	RESTGetTemplateData             = api.RESTGetTemplateData
-	RESTGetListAPIKeysData          = api.RESTGetListAPIKeysData
-	RESTGetListAPIKeysQueryParams   = api.RESTGetListAPIKeysQueryParams
-	RESTPostCreateAPIKeyData        = api.RESTPostCreateAPIKeyData
-	RESTPostCreateAPIKeyBody        = api.RESTPostCreateAPIKeyBody
-	RESTDeleteAPIKeyData            = api.RESTDeleteAPIKeyData
+	RESTGetWebhookData                 = api.RESTGetWebhookData
+	RESTPostCreateWebhookData          = api.RESTPostCreateWebhookData
+	RESTPostCreateWebhookBody          = api.RESTPostCreateWebhookBody
+	RESTDeleteWebhookData              = api.RESTDeleteWebhookData
+	RESTPatchUpdateWebhookData         = api.RESTPatchUpdateWebhookData
+	RESTPatchUpdateWebhookBody         = api.RESTPatchUpdateWebhookBody
+	RESTGetListWebhooksData            = api.RESTGetListWebhooksData
+	RESTGetListWebhooksQueryParams     = api.RESTGetListWebhooksQueryParams
+	RESTGetListTemplatesData           = api.RESTGetListTemplatesData
+	RESTGetListTemplatesQueryParams    = api.RESTGetListTemplatesQueryParams
+	RESTPostCreateTemplateData         = api.RESTPostCreateTemplateData
+	RESTPostCreateTemplateBody         = api.RESTPostCreateTemplateBody
+	RESTPatchUpdateTemplateData        = api.RESTPatchUpdateTemplateData
+	RESTPatchUpdateTemplateBody        = api.RESTPatchUpdateTemplateBody
+	RESTDeleteTemplateData             = api.RESTDeleteTemplateData
+	RESTGetTemplateData                = api.RESTGetTemplateData
+	RESTGetListAPIKeysData             = api.RESTGetListAPIKeysData
+	RESTGetListAPIKeysQueryParams      = api.RESTGetListAPIKeysQueryParams
+	RESTPostCreateAPIKeyData           = api.RESTPostCreateAPIKeyData
+	RESTPostCreateAPIKeyBody           = api.RESTPostCreateAPIKeyBody
+	RESTDeleteAPIKeyData               = api.RESTDeleteAPIKeyData
+	RESTPostSendMessageData            = api.RESTPostSendMessageData
+	RESTPostSendMessageBody            = api.RESTPostSendMessageBody
+	RESTPostSendMessageTemplate        = api.RESTPostSendMessageTemplate
+	RESTGetMessageData                 = api.RESTGetMessageData
+	RESTGetListMessagesData            = api.RESTGetListMessagesData
+	RESTGetListMessagesQueryParams     = api.RESTGetListMessagesQueryParams
+	RESTPostCancelMessageData          = api.RESTPostCancelMessageData
+	RESTGetProjectData                 = api.RESTGetProjectData
+	RESTGetListProjectsData            = api.RESTGetListProjectsData
+	RESTGetListProjectsQueryParams     = api.RESTGetListProjectsQueryParams
+	RESTPatchUpdateProjectData         = api.RESTPatchUpdateProjectData
+	RESTPatchUpdateProjectBody         = api.RESTPatchUpdateProjectBody
+	RESTGetBalanceData                 = api.RESTGetBalanceData
+	RESTGetUsageData                   = api.RESTGetUsageData
+	RESTGetUsageQueryParams            = api.RESTGetUsageQueryParams
+	RESTGetListTransactionsData        = api.RESTGetListTransactionsData
+	RESTGetListTransactionsQueryParams = api.RESTGetListTransactionsQueryParams
+	RESTGetListInvoicesData            = api.RESTGetListInvoicesData
+	RESTGetListInvoicesQueryParams     = api.RESTGetListInvoicesQueryParams
+	RESTGetInvoiceData                 = api.RESTGetInvoiceData
 )
 
 // APIKey scope constants.
@@ -91,6 +224,38 @@ const (
 	APIKeyScopeWriteWebhooks = api.APIKeyScopeWriteWebhooks
 )
 
+// Scope helpers.
+var (
+	// ParseScope validates a scope string and returns its canonical constant.
+	ParseScope = api.ParseScope
+	// ParseScopes parses and deduplicates scope strings.
+	ParseScopes = api.ParseScopes
+	// KnownScopes returns every scope the SDK knows about, excluding the wildcard.
+	KnownScopes = api.KnownScopes
+	// OperationScopes returns the scopes a resource method needs, e.g. "Templates.Create".
+	OperationScopes = resources.OperationScopes
+	// MinimalScopes returns the smallest scope set that allows every given operation.
+	MinimalScopes = resources.MinimalScopes
+	// Operations returns every resource method with a declared scope.
+	Operations = resources.Operations
+	// UnscopedOperations returns every resource method without a known scope.
+	UnscopedOperations = resources.UnscopedOperations
+)
+
+// TemplateContentMaxLength is the assumed longest template content, applied only by strict validation.
+const TemplateContentMaxLength = api.TemplateContentMaxLength
+
+// Template helpers.
+var (
+	// RenderTemplate previews content as a template message, under the assumptions it documents.
+	RenderTemplate = api.RenderTemplate
+	// TemplatePlaceholders returns the distinct placeholder names in content.
+	TemplatePlaceholders = api.TemplatePlaceholders
+)
+
+// ErrMissingScope matches ScopeError through errors.Is.
+var ErrMissingScope = rest.ErrMissingScope
+
 // Webhook event/status constants.
 const (
 	WebhookEventTypeSMSQueued    = api.WebhookEventTypeSMSQueued
@@ -101,3 +266,22 @@ const (
 	WebhookStatusActive          = api.WebhookStatusActive
 	WebhookStatusInactive        = api.WebhookStatusInactive
 )
+
+// Message status constants.
+const (
+	MessageStatusQueued    = api.MessageStatusQueued
+	MessageStatusScheduled = api.MessageStatusScheduled
+	MessageStatusDelivered = api.MessageStatusDelivered
+	MessageStatusFailed    = api.MessageStatusFailed
+	MessageStatusCanceled  = api.MessageStatusCanceled
+)
+
+// Transaction type and invoice status constants.
+const (
+	TransactionTypeCredit = api.TransactionTypeCredit
+	TransactionTypeDebit  = api.TransactionTypeDebit
+	TransactionTypeRefund = api.TransactionTypeRefund
+	InvoiceStatusOpen     = api.InvoiceStatusOpen
+	InvoiceStatusPaid     = api.InvoiceStatusPaid
+	InvoiceStatusVoid     = api.InvoiceStatusVoid
+)
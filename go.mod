@@ -1,7 +1,3 @@
 module github.com/rewritetoday/golang
 
-go 1.22.2
-
-require github.com/go-resty/resty/v2 v2.16.5
-
-require golang.org/x/net v0.33.0 // indirect
+go 1.23.0
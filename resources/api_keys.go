@@ -2,6 +2,9 @@ package resources
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"iter"
 
 	"github.com/rewritetoday/golang/api"
 )
@@ -14,24 +17,170 @@ type APIKeys struct {
 // CreateAPIKeyOptions carries API key creation input plus the target project ID.
 type CreateAPIKeyOptions struct {
 	Project string `json:"-"`
+	// IdempotencyKey overrides the key generated for this request.
+	IdempotencyKey string `json:"-"`
 	api.RESTPostCreateAPIKeyBody
 }
 
+// UpdateAPIKeyOptions carries API key update input plus the target project ID.
+type UpdateAPIKeyOptions struct {
+	Project string `json:"-"`
+	api.RESTPatchUpdateAPIKeyBody
+}
+
 // Create creates an API key for a project.
 func (r *APIKeys) Create(ctx context.Context, options CreateAPIKeyOptions) (api.RESTPostCreateAPIKeyData, error) {
 	var out api.RESTPostCreateAPIKeyData
-	err := r.Rest.Post(ctx, api.Routes.APIKeys.Create(options.Project), options.RESTPostCreateAPIKeyBody, &out, nil)
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Post(ctx, api.Routes.APIKeys.Create(project), options.RESTPostCreateAPIKeyBody, &out, callOptions("APIKeys.Create", options.IdempotencyKey))
 	return out, err
 }
 
 // Delete deletes an API key by ID.
 func (r *APIKeys) Delete(ctx context.Context, id, project string) error {
-	return r.Rest.Delete(ctx, api.Routes.APIKeys.Delete(project, id), nil, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return err
+	}
+	return r.Rest.Delete(ctx, api.Routes.APIKeys.Delete(project, id), nil, callOptions("APIKeys.Delete", ""))
 }
 
 // List lists API keys for a project.
 func (r *APIKeys) List(ctx context.Context, project string, query *api.RESTGetListAPIKeysQueryParams) (api.RESTGetListAPIKeysData, error) {
 	var out api.RESTGetListAPIKeysData
-	err := r.Rest.Get(ctx, api.Routes.APIKeys.List(project, query), &out, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.APIKeys.List(project, query), &out, callOptions("APIKeys.List", ""))
+	return out, err
+}
+
+// All iterates over every API key in a project, fetching pages as needed.
+func (r *APIKeys) All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIAPIKey, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APIAPIKey], error) {
+		return r.List(ctx, project, query)
+	}, options)
+}
+
+// Get fetches an API key by ID. The secret is never returned.
+func (r *APIKeys) Get(ctx context.Context, id, project string) (api.RESTGetAPIKeyData, error) {
+	var out api.RESTGetAPIKeyData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.APIKeys.Get(project, id), &out, callOptions("APIKeys.Get", ""))
 	return out, err
 }
+
+// GetData fetches an API key by ID and returns only the response data.
+func (r *APIKeys) GetData(ctx context.Context, id, project string) (api.APIAPIKey, error) {
+	return Data(r.Get(ctx, id, project))
+}
+
+// Update renames an API key or replaces its scopes.
+func (r *APIKeys) Update(ctx context.Context, id string, options UpdateAPIKeyOptions) (api.RESTPatchUpdateAPIKeyData, error) {
+	var out api.RESTPatchUpdateAPIKeyData
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Patch(ctx, api.Routes.APIKeys.Update(project, id), options.RESTPatchUpdateAPIKeyBody, &out, callOptions("APIKeys.Update", ""))
+	return out, err
+}
+
+// RotateAPIKeyOptions configures Rotate.
+type RotateAPIKeyOptions struct {
+	Project string
+	// Name names the replacement key. Defaults to the name of the rotated key.
+	Name string
+	// IdempotencyKey overrides the key generated for the create request.
+	IdempotencyKey string
+	// Activate receives the replacement key and should return once every
+	// consumer uses it. Returning an error deletes the replacement and keeps
+	// the rotated key.
+	Activate func(ctx context.Context, key api.APICreatedAPIKey) error
+}
+
+// RotationStage names the Rotate step that failed.
+type RotationStage string
+
+const (
+	// RotationStageActivate means Activate failed and the replacement key was rolled back.
+	RotationStageActivate RotationStage = "activate"
+	// RotationStageRevoke means the replacement key is live but the rotated key could not be deleted.
+	RotationStageRevoke RotationStage = "revoke"
+)
+
+// RotationError is returned when Rotate fails after the replacement key was created.
+type RotationError struct {
+	Stage RotationStage
+	// Key is the replacement key. After RotationStageActivate it has been deleted
+	// unless RollbackErr is set.
+	Key api.APICreatedAPIKey
+	Err error
+	// RollbackErr is set when the replacement key could not be deleted.
+	RollbackErr error
+}
+
+// Error implements the error interface.
+func (e *RotationError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("API key rotation failed at %s: %v (rollback failed: %v)", e.Stage, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("API key rotation failed at %s: %v", e.Stage, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RotationError) Unwrap() error {
+	return e.Err
+}
+
+// Rotate replaces an API key with a new key holding the same scopes.
+//
+// The replacement is handed to options.Activate, and the old key is only
+// deleted after Activate succeeds. If Activate fails, the replacement is
+// deleted and the old key stays valid.
+func (r *APIKeys) Rotate(ctx context.Context, id string, options RotateAPIKeyOptions) (api.APICreatedAPIKey, error) {
+	if options.Activate == nil {
+		return api.APICreatedAPIKey{}, errors.New("Expected an Activate callback for the rotation")
+	}
+
+	project, err := r.project(options.Project)
+	if err != nil {
+		return api.APICreatedAPIKey{}, err
+	}
+	old, err := r.GetData(ctx, id, project)
+	if err != nil {
+		return api.APICreatedAPIKey{}, err
+	}
+
+	name := options.Name
+	if name == "" {
+		name = old.Name
+	}
+
+	key, err := Data(r.Create(ctx, CreateAPIKeyOptions{
+		Project:                  project,
+		IdempotencyKey:           options.IdempotencyKey,
+		RESTPostCreateAPIKeyBody: api.RESTPostCreateAPIKeyBody{Name: name, Scopes: old.Scopes},
+	}))
+	if err != nil {
+		return api.APICreatedAPIKey{}, err
+	}
+
+	if err := options.Activate(ctx, key); err != nil {
+		rollbackErr := r.Delete(context.WithoutCancel(ctx), string(key.ID), project)
+		return api.APICreatedAPIKey{}, &RotationError{Stage: RotationStageActivate, Key: key, Err: err, RollbackErr: rollbackErr}
+	}
+
+	if err := r.Delete(context.WithoutCancel(ctx), id, project); err != nil {
+		return key, &RotationError{Stage: RotationStageRevoke, Key: key, Err: err}
+	}
+
+	return key, nil
+}
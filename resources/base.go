@@ -1,8 +1,78 @@
 package resources
 
-import "github.com/rewritetoday/golang/rest"
+import (
+	"errors"
+
+	"github.com/rewritetoday/golang/api"
+	"github.com/rewritetoday/golang/rest"
+)
+
+// ErrMissingProject is returned when a call has no project ID and no default project is configured.
+var ErrMissingProject = errors.New("Expected a project ID")
+
+// ValidationError is returned when client-side validation rejects a request before it is sent.
+//
+// It has the same shape as the validation details of an API response and
+// matches rest.ErrValidation through errors.Is.
+type ValidationError struct {
+	// Operation names the SDK method, e.g. Templates.Create.
+	Operation string
+	api.APIValidationError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Is lets errors.Is match rest.ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == rest.ErrValidation
+}
+
+// FieldErrors returns the field-level validation details.
+func (e *ValidationError) FieldErrors() map[string]any {
+	return e.Detailed
+}
+
+// validated wraps validation details in a ValidationError, or returns nil when there are none.
+func validated(operation string, details *api.APIValidationError) error {
+	if details == nil {
+		return nil
+	}
+	return &ValidationError{Operation: operation, APIValidationError: *details}
+}
 
 // Base shares access to the low-level REST client.
 type Base struct {
 	Rest *rest.Client
+	// DefaultProject is used when a call does not specify a project.
+	DefaultProject string
+}
+
+// Data unwraps the Data field of a resource call result.
+//
+//	template, err := resources.Data(client.Templates.Create(ctx, options))
+func Data[T any](response api.APIResponse[T], err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return response.Data, nil
+}
+
+// project resolves the project ID for a call, falling back to DefaultProject.
+func (b *Base) project(project string) (string, error) {
+	if project == "" {
+		project = b.DefaultProject
+	}
+	if project == "" {
+		return "", ErrMissingProject
+	}
+	return project, nil
+}
+
+// callOptions names the operation for observers, declares its scopes and sets an optional idempotency key.
+func callOptions(operation, idempotencyKey string) *rest.FetchOptions {
+	return &rest.FetchOptions{Operation: operation, Scopes: operationScopes[operation], IdempotencyKey: idempotencyKey}
 }
@@ -0,0 +1,102 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Messages provides SMS message resource operations.
+type Messages struct {
+	Base
+}
+
+// SendMessageOptions carries message input plus the target project ID.
+type SendMessageOptions struct {
+	Project string `json:"-"`
+	// IdempotencyKey overrides the key generated for this request.
+	IdempotencyKey string `json:"-"`
+	api.RESTPostSendMessageBody
+}
+
+// CancelMessageOptions carries the target project ID and an optional idempotency key for Cancel.
+type CancelMessageOptions struct {
+	Project string `json:"-"`
+	// IdempotencyKey overrides the key generated for this request.
+	IdempotencyKey string `json:"-"`
+}
+
+// Send sends an SMS using raw content or a template reference.
+func (r *Messages) Send(ctx context.Context, options SendMessageOptions) (api.RESTPostSendMessageData, error) {
+	var out api.RESTPostSendMessageData
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	if err := validateSendMessageBody(options.RESTPostSendMessageBody); err != nil {
+		return out, err
+	}
+	err = r.Rest.Post(ctx, api.Routes.Messages.Send(project), options.RESTPostSendMessageBody, &out, callOptions("Messages.Send", options.IdempotencyKey))
+	return out, err
+}
+
+// Get fetches a message by ID.
+func (r *Messages) Get(ctx context.Context, id, project string) (api.RESTGetMessageData, error) {
+	var out api.RESTGetMessageData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Messages.Get(project, id), &out, callOptions("Messages.Get", ""))
+	return out, err
+}
+
+// GetData fetches a message by ID and returns only the response data.
+func (r *Messages) GetData(ctx context.Context, id, project string) (api.APIMessage, error) {
+	return Data(r.Get(ctx, id, project))
+}
+
+// List lists messages for a project.
+func (r *Messages) List(ctx context.Context, project string, query *api.RESTGetListMessagesQueryParams) (api.RESTGetListMessagesData, error) {
+	var out api.RESTGetListMessagesData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Messages.List(project, query), &out, callOptions("Messages.List", ""))
+	return out, err
+}
+
+// All iterates over every message in a project, fetching pages as needed.
+func (r *Messages) All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIMessage, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APIMessage], error) {
+		return r.List(ctx, project, query)
+	}, options)
+}
+
+// Cancel cancels a queued or scheduled message by ID.
+func (r *Messages) Cancel(ctx context.Context, id string, options CancelMessageOptions) (api.RESTPostCancelMessageData, error) {
+	var out api.RESTPostCancelMessageData
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Post(ctx, api.Routes.Messages.Cancel(project, id), nil, &out, callOptions("Messages.Cancel", options.IdempotencyKey))
+	return out, err
+}
+
+func validateSendMessageBody(body api.RESTPostSendMessageBody) error {
+	if body.To == "" {
+		return errors.New("Expected a recipient for the message")
+	}
+	hasTemplate := body.Template != nil && body.Template.Identifier != ""
+	if body.Content == "" && !hasTemplate {
+		return errors.New("Expected either content or a template for the message")
+	}
+	if body.Content != "" && hasTemplate {
+		return errors.New("Expected only one of content or template for the message")
+	}
+	return nil
+}
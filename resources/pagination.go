@@ -0,0 +1,120 @@
+package resources
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// PageFunc fetches a single page of a list endpoint.
+type PageFunc[T any] func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]T], error)
+
+// PaginateOptions configures auto-pagination.
+//
+// Limit is the page size. After or Before set the starting cursor; when only
+// Before is set, pages are walked backwards.
+type PaginateOptions struct {
+	api.RESTCursorOptions
+	// MaxItems stops iteration after this many items. Zero means no cap.
+	MaxItems int
+}
+
+// Pager walks a cursor-paginated list endpoint page by page.
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	query    api.RESTCursorOptions
+	backward bool
+	maxItems int
+	count    int
+	done     bool
+}
+
+// NewPager creates a Pager for fetch.
+func NewPager[T any](fetch PageFunc[T], options *PaginateOptions) *Pager[T] {
+	p := &Pager[T]{fetch: fetch}
+	if options != nil {
+		p.query = options.RESTCursorOptions
+		p.maxItems = options.MaxItems
+	}
+	p.backward = p.query.After == "" && p.query.Before != ""
+	return p
+}
+
+// Done reports whether the last page has been fetched.
+func (p *Pager[T]) Done() bool {
+	return p.done
+}
+
+// Next fetches the next page. It returns nil once Done reports true.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	query := p.query
+	page, err := p.fetch(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	items := page.Data
+	if p.maxItems > 0 && p.count+len(items) > p.maxItems {
+		items = items[:p.maxItems-p.count]
+	}
+	p.count += len(items)
+
+	cursor := page.Cursor
+	switch {
+	case cursor == nil || !cursor.Persist || cursor.Next == nil || len(page.Data) == 0:
+		p.done = true
+	case p.maxItems > 0 && p.count >= p.maxItems:
+		p.done = true
+	case p.backward:
+		p.query.Before = *cursor.Next
+	default:
+		p.query.After = *cursor.Next
+	}
+
+	return items, nil
+}
+
+// All returns an iterator over every remaining item.
+// Iteration stops after the first error, which is yielded with a zero item.
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for !p.done {
+			items, err := p.Next(ctx)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Paginate returns an iterator over every item of a list endpoint.
+// Each range over the iterator starts again from the first page.
+func Paginate[T any](ctx context.Context, fetch PageFunc[T], options *PaginateOptions) iter.Seq2[T, error] {
+	var start *PaginateOptions
+	if options != nil {
+		copied := *options
+		start = &copied
+	}
+	return func(yield func(T, error) bool) {
+		NewPager(fetch, start).All(ctx)(yield)
+	}
+}
@@ -0,0 +1,111 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+func fakePages(t *testing.T, pages map[api.Snowflake][]int, queries *[]api.RESTCursorOptions) PageFunc[int] {
+	t.Helper()
+	return func(_ context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]int], error) {
+		*queries = append(*queries, *query)
+		key := query.After
+		if key == "" {
+			key = query.Before
+		}
+		data := pages[key]
+		next := api.Snowflake(string(key) + "x")
+		_, persist := pages[next]
+		return api.APIResponse[[]int]{OK: true, Data: data, Cursor: &api.Cursor{Persist: persist, Next: &next}}, nil
+	}
+}
+
+func TestPaginateFollowsCursor(t *testing.T) {
+	var queries []api.RESTCursorOptions
+	fetch := fakePages(t, map[api.Snowflake][]int{"": {1, 2}, "x": {3, 4}, "xx": {5}}, &queries)
+
+	var got []int
+	for item, err := range Paginate(context.Background(), fetch, nil) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, item)
+	}
+
+	if len(got) != 5 || got[4] != 5 {
+		t.Fatalf("unexpected items: %v", got)
+	}
+	if len(queries) != 3 || queries[1].After != "x" || queries[2].After != "xx" {
+		t.Fatalf("unexpected queries: %+v", queries)
+	}
+}
+
+func TestPaginateRestartsOnEachRange(t *testing.T) {
+	var queries []api.RESTCursorOptions
+	fetch := fakePages(t, map[api.Snowflake][]int{"": {1, 2}, "x": {3}}, &queries)
+
+	items := Paginate(context.Background(), fetch, nil)
+	for range 2 {
+		count := 0
+		for _, err := range items {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+		}
+		if count != 3 {
+			t.Fatalf("expected every range to yield 3 items, got %d", count)
+		}
+	}
+	if len(queries) != 4 {
+		t.Fatalf("expected each range to refetch both pages, got %+v", queries)
+	}
+}
+
+func TestPaginateMaxItemsAndBackward(t *testing.T) {
+	var queries []api.RESTCursorOptions
+	fetch := fakePages(t, map[api.Snowflake][]int{"b": {1, 2}, "bx": {3, 4}, "bxx": {5}}, &queries)
+
+	var got []int
+	options := &PaginateOptions{RESTCursorOptions: api.RESTCursorOptions{Before: "b"}, MaxItems: 3}
+	for item, err := range Paginate(context.Background(), fetch, options) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, item)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected 3 items, got %v", got)
+	}
+	if len(queries) != 2 || queries[1].Before != "bx" || queries[1].After != "" {
+		t.Fatalf("unexpected queries: %+v", queries)
+	}
+}
+
+func TestPaginateStopsOnCanceledContext(t *testing.T) {
+	var queries []api.RESTCursorOptions
+	fetch := fakePages(t, map[api.Snowflake][]int{"": {1, 2}, "x": {3}}, &queries)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var lastErr error
+	for _, err := range Paginate(ctx, fetch, nil) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		cancel()
+	}
+
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", lastErr)
+	}
+	if len(queries) != 1 {
+		t.Fatalf("expected a single fetch, got %d", len(queries))
+	}
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Payments provides read-only billing operations for a project.
+type Payments struct {
+	Base
+}
+
+// Balance fetches the current prepaid balance of a project.
+func (r *Payments) Balance(ctx context.Context, project string) (api.RESTGetBalanceData, error) {
+	var out api.RESTGetBalanceData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Payments.Balance(project), &out, callOptions("Payments.Balance", ""))
+	return out, err
+}
+
+// Usage fetches credit usage for a period. A nil query selects the current month.
+func (r *Payments) Usage(ctx context.Context, project string, query *api.RESTGetUsageQueryParams) (api.RESTGetUsageData, error) {
+	var out api.RESTGetUsageData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Payments.Usage(project, query), &out, callOptions("Payments.Usage", ""))
+	return out, err
+}
+
+// Transactions lists balance movements for a project.
+func (r *Payments) Transactions(ctx context.Context, project string, query *api.RESTGetListTransactionsQueryParams) (api.RESTGetListTransactionsData, error) {
+	var out api.RESTGetListTransactionsData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Payments.Transactions(project, query), &out, callOptions("Payments.Transactions", ""))
+	return out, err
+}
+
+// AllTransactions iterates over every transaction in a project, fetching pages as needed.
+func (r *Payments) AllTransactions(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APITransaction, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APITransaction], error) {
+		return r.Transactions(ctx, project, query)
+	}, options)
+}
+
+// Invoices lists invoices for a project.
+func (r *Payments) Invoices(ctx context.Context, project string, query *api.RESTGetListInvoicesQueryParams) (api.RESTGetListInvoicesData, error) {
+	var out api.RESTGetListInvoicesData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Payments.Invoices(project, query), &out, callOptions("Payments.Invoices", ""))
+	return out, err
+}
+
+// AllInvoices iterates over every invoice in a project, fetching pages as needed.
+func (r *Payments) AllInvoices(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIInvoice, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APIInvoice], error) {
+		return r.Invoices(ctx, project, query)
+	}, options)
+}
+
+// Invoice fetches an invoice by ID.
+func (r *Payments) Invoice(ctx context.Context, id, project string) (api.RESTGetInvoiceData, error) {
+	var out api.RESTGetInvoiceData
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Payments.Invoice(project, id), &out, callOptions("Payments.Invoice", ""))
+	return out, err
+}
@@ -0,0 +1,313 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// ProjectScope exposes resource operations bound to a single project.
+type ProjectScope struct {
+	// ID is the bound project ID.
+	ID string
+
+	// APIKeys exposes API key operations for the project.
+	APIKeys *ProjectAPIKeys
+
+	// Templates exposes template operations for the project.
+	Templates *ProjectTemplates
+
+	// Webhooks exposes webhook operations for the project.
+	Webhooks *ProjectWebhooks
+
+	// Messages exposes SMS message operations for the project.
+	Messages *ProjectMessages
+
+	// Payments exposes billing operations for the project.
+	Payments *ProjectPayments
+
+	projects *Projects
+}
+
+// NewProjectScope binds the resource clients sharing base to project id.
+func NewProjectScope(base Base, id string) *ProjectScope {
+	return &ProjectScope{
+		ID:        id,
+		APIKeys:   &ProjectAPIKeys{resource: &APIKeys{Base: base}, project: id},
+		Templates: &ProjectTemplates{resource: &Templates{Base: base}, project: id},
+		Webhooks:  &ProjectWebhooks{resource: &Webhooks{Base: base}, project: id},
+		Messages:  &ProjectMessages{resource: &Messages{Base: base}, project: id},
+		Payments:  &ProjectPayments{resource: &Payments{Base: base}, project: id},
+		projects:  &Projects{Base: base},
+	}
+}
+
+// Get fetches the bound project.
+func (s *ProjectScope) Get(ctx context.Context) (api.RESTGetProjectData, error) {
+	return s.projects.Get(ctx, s.ID)
+}
+
+// Update updates the bound project.
+func (s *ProjectScope) Update(ctx context.Context, body api.RESTPatchUpdateProjectBody) (api.RESTPatchUpdateProjectData, error) {
+	return s.projects.Update(ctx, UpdateProjectOptions{Project: s.ID, RESTPatchUpdateProjectBody: body})
+}
+
+// scoped checks that an options-level project, when set, matches the bound project.
+func scoped(bound, project string) (string, error) {
+	if project != "" && project != bound {
+		return "", fmt.Errorf("Expected project %s but the options target project %s", bound, project)
+	}
+	return bound, nil
+}
+
+// ProjectTemplates provides template operations bound to a project.
+type ProjectTemplates struct {
+	resource *Templates
+	project  string
+}
+
+// Create creates a template in the bound project.
+func (r *ProjectTemplates) Create(ctx context.Context, options CreateTemplateOptions) (api.RESTPostCreateTemplateData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPostCreateTemplateData{}, err
+	}
+	options.Project = project
+	return r.resource.Create(ctx, options)
+}
+
+// Update updates a template by ID.
+func (r *ProjectTemplates) Update(ctx context.Context, id string, options UpdateTemplateOptions) (api.RESTPatchUpdateTemplateData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPatchUpdateTemplateData{}, err
+	}
+	options.Project = project
+	return r.resource.Update(ctx, id, options)
+}
+
+// Delete deletes a template by ID.
+func (r *ProjectTemplates) Delete(ctx context.Context, id string) error {
+	return r.resource.Delete(ctx, id, r.project)
+}
+
+// List lists templates in the bound project.
+func (r *ProjectTemplates) List(ctx context.Context, query *api.RESTGetListTemplatesQueryParams) (api.RESTGetListTemplatesData, error) {
+	return r.resource.List(ctx, r.project, query)
+}
+
+// All iterates over every template in the bound project.
+func (r *ProjectTemplates) All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APITemplate, error] {
+	return r.resource.All(ctx, r.project, options)
+}
+
+// Get fetches a template by ID or unique name.
+func (r *ProjectTemplates) Get(ctx context.Context, identifier string) (api.RESTGetTemplateData, error) {
+	return r.resource.Get(ctx, identifier, r.project)
+}
+
+// GetData fetches a template by ID or unique name and returns only the response data.
+func (r *ProjectTemplates) GetData(ctx context.Context, identifier string) (api.APITemplate, error) {
+	return r.resource.GetData(ctx, identifier, r.project)
+}
+
+// ProjectWebhooks provides webhook operations bound to a project.
+type ProjectWebhooks struct {
+	resource *Webhooks
+	project  string
+}
+
+// Create creates a webhook in the bound project.
+func (r *ProjectWebhooks) Create(ctx context.Context, options CreateWebhookOptions) (api.RESTPostCreateWebhookData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPostCreateWebhookData{}, err
+	}
+	options.Project = project
+	return r.resource.Create(ctx, options)
+}
+
+// Update updates a webhook by ID.
+func (r *ProjectWebhooks) Update(ctx context.Context, id string, options UpdateWebhookOptions) (api.RESTPatchUpdateWebhookData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPatchUpdateWebhookData{}, err
+	}
+	options.Project = project
+	return r.resource.Update(ctx, id, options)
+}
+
+// Delete deletes a webhook by ID.
+func (r *ProjectWebhooks) Delete(ctx context.Context, id string) error {
+	return r.resource.Delete(ctx, id, r.project)
+}
+
+// List lists webhooks in the bound project.
+func (r *ProjectWebhooks) List(ctx context.Context, query *api.RESTGetListWebhooksQueryParams) (api.RESTGetListWebhooksData, error) {
+	return r.resource.List(ctx, r.project, query)
+}
+
+// All iterates over every webhook in the bound project.
+func (r *ProjectWebhooks) All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIWebhook, error] {
+	return r.resource.All(ctx, r.project, options)
+}
+
+// Get fetches a webhook by ID.
+func (r *ProjectWebhooks) Get(ctx context.Context, id string) (api.RESTGetWebhookData, error) {
+	return r.resource.Get(ctx, id, r.project)
+}
+
+// GetData fetches a webhook by ID and returns only the response data.
+func (r *ProjectWebhooks) GetData(ctx context.Context, id string) (api.APIWebhook, error) {
+	return r.resource.GetData(ctx, id, r.project)
+}
+
+// ProjectAPIKeys provides API key operations bound to a project.
+type ProjectAPIKeys struct {
+	resource *APIKeys
+	project  string
+}
+
+// Create creates an API key in the bound project.
+func (r *ProjectAPIKeys) Create(ctx context.Context, options CreateAPIKeyOptions) (api.RESTPostCreateAPIKeyData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPostCreateAPIKeyData{}, err
+	}
+	options.Project = project
+	return r.resource.Create(ctx, options)
+}
+
+// Delete deletes an API key by ID.
+func (r *ProjectAPIKeys) Delete(ctx context.Context, id string) error {
+	return r.resource.Delete(ctx, id, r.project)
+}
+
+// List lists API keys in the bound project.
+func (r *ProjectAPIKeys) List(ctx context.Context, query *api.RESTGetListAPIKeysQueryParams) (api.RESTGetListAPIKeysData, error) {
+	return r.resource.List(ctx, r.project, query)
+}
+
+// All iterates over every API key in the bound project.
+func (r *ProjectAPIKeys) All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIAPIKey, error] {
+	return r.resource.All(ctx, r.project, options)
+}
+
+// Get fetches an API key by ID.
+func (r *ProjectAPIKeys) Get(ctx context.Context, id string) (api.RESTGetAPIKeyData, error) {
+	return r.resource.Get(ctx, id, r.project)
+}
+
+// GetData fetches an API key by ID and returns only the response data.
+func (r *ProjectAPIKeys) GetData(ctx context.Context, id string) (api.APIAPIKey, error) {
+	return r.resource.GetData(ctx, id, r.project)
+}
+
+// Update renames an API key or replaces its scopes.
+func (r *ProjectAPIKeys) Update(ctx context.Context, id string, options UpdateAPIKeyOptions) (api.RESTPatchUpdateAPIKeyData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPatchUpdateAPIKeyData{}, err
+	}
+	options.Project = project
+	return r.resource.Update(ctx, id, options)
+}
+
+// Rotate replaces an API key with a new key holding the same scopes.
+func (r *ProjectAPIKeys) Rotate(ctx context.Context, id string, options RotateAPIKeyOptions) (api.APICreatedAPIKey, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.APICreatedAPIKey{}, err
+	}
+	options.Project = project
+	return r.resource.Rotate(ctx, id, options)
+}
+
+// ProjectMessages provides SMS message operations bound to a project.
+type ProjectMessages struct {
+	resource *Messages
+	project  string
+}
+
+// Send sends an SMS from the bound project.
+func (r *ProjectMessages) Send(ctx context.Context, options SendMessageOptions) (api.RESTPostSendMessageData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPostSendMessageData{}, err
+	}
+	options.Project = project
+	return r.resource.Send(ctx, options)
+}
+
+// Get fetches a message by ID.
+func (r *ProjectMessages) Get(ctx context.Context, id string) (api.RESTGetMessageData, error) {
+	return r.resource.Get(ctx, id, r.project)
+}
+
+// GetData fetches a message by ID and returns only the response data.
+func (r *ProjectMessages) GetData(ctx context.Context, id string) (api.APIMessage, error) {
+	return r.resource.GetData(ctx, id, r.project)
+}
+
+// List lists messages in the bound project.
+func (r *ProjectMessages) List(ctx context.Context, query *api.RESTGetListMessagesQueryParams) (api.RESTGetListMessagesData, error) {
+	return r.resource.List(ctx, r.project, query)
+}
+
+// All iterates over every message in the bound project.
+func (r *ProjectMessages) All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIMessage, error] {
+	return r.resource.All(ctx, r.project, options)
+}
+
+// Cancel cancels a queued or scheduled message by ID.
+func (r *ProjectMessages) Cancel(ctx context.Context, id string, options CancelMessageOptions) (api.RESTPostCancelMessageData, error) {
+	project, err := scoped(r.project, options.Project)
+	if err != nil {
+		return api.RESTPostCancelMessageData{}, err
+	}
+	options.Project = project
+	return r.resource.Cancel(ctx, id, options)
+}
+
+// ProjectPayments provides billing operations bound to a project.
+type ProjectPayments struct {
+	resource *Payments
+	project  string
+}
+
+// Balance fetches the current prepaid balance of the bound project.
+func (r *ProjectPayments) Balance(ctx context.Context) (api.RESTGetBalanceData, error) {
+	return r.resource.Balance(ctx, r.project)
+}
+
+// Usage fetches credit usage for a period. A nil query selects the current month.
+func (r *ProjectPayments) Usage(ctx context.Context, query *api.RESTGetUsageQueryParams) (api.RESTGetUsageData, error) {
+	return r.resource.Usage(ctx, r.project, query)
+}
+
+// Transactions lists balance movements for the bound project.
+func (r *ProjectPayments) Transactions(ctx context.Context, query *api.RESTGetListTransactionsQueryParams) (api.RESTGetListTransactionsData, error) {
+	return r.resource.Transactions(ctx, r.project, query)
+}
+
+// AllTransactions iterates over every transaction in the bound project.
+func (r *ProjectPayments) AllTransactions(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APITransaction, error] {
+	return r.resource.AllTransactions(ctx, r.project, options)
+}
+
+// Invoices lists invoices for the bound project.
+func (r *ProjectPayments) Invoices(ctx context.Context, query *api.RESTGetListInvoicesQueryParams) (api.RESTGetListInvoicesData, error) {
+	return r.resource.Invoices(ctx, r.project, query)
+}
+
+// AllInvoices iterates over every invoice in the bound project.
+func (r *ProjectPayments) AllInvoices(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIInvoice, error] {
+	return r.resource.AllInvoices(ctx, r.project, options)
+}
+
+// Invoice fetches an invoice by ID.
+func (r *ProjectPayments) Invoice(ctx context.Context, id string) (api.RESTGetInvoiceData, error) {
+	return r.resource.Invoice(ctx, id, r.project)
+}
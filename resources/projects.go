@@ -0,0 +1,60 @@
+package resources
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Projects provides project resource operations.
+type Projects struct {
+	Base
+}
+
+// UpdateProjectOptions carries project update input plus the target project ID.
+type UpdateProjectOptions struct {
+	Project string `json:"-"`
+	api.RESTPatchUpdateProjectBody
+}
+
+// Get fetches a project by ID.
+func (r *Projects) Get(ctx context.Context, id string) (api.RESTGetProjectData, error) {
+	var out api.RESTGetProjectData
+	project, err := r.project(id)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Projects.Get(project), &out, callOptions("Projects.Get", ""))
+	return out, err
+}
+
+// GetData fetches a project by ID and returns only the response data.
+func (r *Projects) GetData(ctx context.Context, id string) (api.APIProject, error) {
+	return Data(r.Get(ctx, id))
+}
+
+// Update updates a project's name, sender IDs or defaults.
+func (r *Projects) Update(ctx context.Context, options UpdateProjectOptions) (api.RESTPatchUpdateProjectData, error) {
+	var out api.RESTPatchUpdateProjectData
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Patch(ctx, api.Routes.Projects.Update(project), options.RESTPatchUpdateProjectBody, &out, callOptions("Projects.Update", ""))
+	return out, err
+}
+
+// List lists the projects visible to an account-level API key.
+func (r *Projects) List(ctx context.Context, query *api.RESTGetListProjectsQueryParams) (api.RESTGetListProjectsData, error) {
+	var out api.RESTGetListProjectsData
+	err := r.Rest.Get(ctx, api.Routes.Projects.List(query), &out, callOptions("Projects.List", ""))
+	return out, err
+}
+
+// All iterates over every project visible to the API key, fetching pages as needed.
+func (r *Projects) All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIProject, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APIProject], error) {
+		return r.List(ctx, query)
+	}, options)
+}
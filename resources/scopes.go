@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// operationScopes lists the scopes each resource method needs. Methods the API
+// publishes no scope for are in unscopedOperations instead.
+var operationScopes = map[string]api.Scopes{
+	"Templates.Create": {api.APIKeyScopeWriteTemplate},
+	"Templates.Update": {api.APIKeyScopeWriteTemplate},
+	"Templates.Delete": {api.APIKeyScopeWriteTemplate},
+	"Templates.List":   {api.APIKeyScopeReadTemplates},
+	"Templates.Get":    {api.APIKeyScopeReadTemplates},
+
+	"Webhooks.Create": {api.APIKeyScopeWriteWebhooks},
+	"Webhooks.Update": {api.APIKeyScopeWriteWebhooks},
+	"Webhooks.Delete": {api.APIKeyScopeWriteWebhooks},
+	"Webhooks.List":   {api.APIKeyScopeReadWebhooks},
+	"Webhooks.Get":    {api.APIKeyScopeReadWebhooks},
+
+	"APIKeys.List": {api.APIKeyScopeReadAPIKeys},
+	"APIKeys.Get":  {api.APIKeyScopeReadAPIKeys},
+
+	"Projects.Get":    {api.APIKeyScopeReadProject},
+	"Projects.List":   {api.APIKeyScopeReadProject},
+	"Projects.Update": {api.APIKeyScopeWriteProject},
+
+	"Payments.Balance":      {api.APIKeyScopeReadPayments},
+	"Payments.Usage":        {api.APIKeyScopeReadPayments},
+	"Payments.Transactions": {api.APIKeyScopeReadPayments},
+	"Payments.Invoices":     {api.APIKeyScopeReadPayments},
+	"Payments.Invoice":      {api.APIKeyScopeReadPayments},
+}
+
+// unscopedOperations are resource methods without a known scope. They skip the local scope check.
+// APIKeys.Rotate is here because it creates and deletes keys; its APIKeys.Get step is still checked.
+var unscopedOperations = []string{
+	"APIKeys.Create",
+	"APIKeys.Update",
+	"APIKeys.Delete",
+	"APIKeys.Rotate",
+	"Messages.Send",
+	"Messages.Cancel",
+	"Messages.List",
+	"Messages.Get",
+}
+
+// Operations returns the names of every resource method with a declared scope, sorted.
+func Operations() []string {
+	operations := make([]string, 0, len(operationScopes))
+	for operation := range operationScopes {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// UnscopedOperations returns the names of every resource method without a known scope, sorted.
+// MinimalScopes accepts them but adds no scope for them.
+func UnscopedOperations() []string {
+	operations := slices.Clone(unscopedOperations)
+	sort.Strings(operations)
+	return operations
+}
+
+// OperationScopes returns the scopes a resource method needs, e.g. "Templates.Create".
+func OperationScopes(operation string) (api.Scopes, bool) {
+	scopes, ok := operationScopes[operation]
+	return slices.Clone(scopes), ok
+}
+
+// MinimalScopes returns the smallest scope set that allows every operation.
+// Operations without a known scope add nothing to it.
+func MinimalScopes(operations ...string) (api.Scopes, error) {
+	var out api.Scopes
+	for _, operation := range operations {
+		scopes, ok := operationScopes[operation]
+		if !ok && !slices.Contains(unscopedOperations, operation) {
+			return nil, fmt.Errorf("Unknown operation %q", operation)
+		}
+		for _, scope := range scopes {
+			if !slices.Contains(out, scope) {
+				out = append(out, scope)
+			}
+		}
+	}
+	return out, nil
+}
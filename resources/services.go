@@ -0,0 +1,84 @@
+package resources
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// The service interfaces let callers depend on resource operations without the
+// concrete clients, so tests can substitute mocks or fakes.
+
+// TemplatesService is the method set of *Templates.
+type TemplatesService interface {
+	Create(ctx context.Context, options CreateTemplateOptions) (api.RESTPostCreateTemplateData, error)
+	Update(ctx context.Context, id string, options UpdateTemplateOptions) (api.RESTPatchUpdateTemplateData, error)
+	Delete(ctx context.Context, id, project string) error
+	List(ctx context.Context, project string, query *api.RESTGetListTemplatesQueryParams) (api.RESTGetListTemplatesData, error)
+	All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APITemplate, error]
+	Get(ctx context.Context, identifier, project string) (api.RESTGetTemplateData, error)
+	GetData(ctx context.Context, identifier, project string) (api.APITemplate, error)
+}
+
+// WebhooksService is the method set of *Webhooks.
+type WebhooksService interface {
+	Create(ctx context.Context, options CreateWebhookOptions) (api.RESTPostCreateWebhookData, error)
+	Update(ctx context.Context, id string, options UpdateWebhookOptions) (api.RESTPatchUpdateWebhookData, error)
+	Delete(ctx context.Context, id, project string) error
+	List(ctx context.Context, project string, query *api.RESTGetListWebhooksQueryParams) (api.RESTGetListWebhooksData, error)
+	All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIWebhook, error]
+	Get(ctx context.Context, id, project string) (api.RESTGetWebhookData, error)
+	GetData(ctx context.Context, id, project string) (api.APIWebhook, error)
+}
+
+// APIKeysService is the method set of *APIKeys.
+type APIKeysService interface {
+	Create(ctx context.Context, options CreateAPIKeyOptions) (api.RESTPostCreateAPIKeyData, error)
+	Update(ctx context.Context, id string, options UpdateAPIKeyOptions) (api.RESTPatchUpdateAPIKeyData, error)
+	Delete(ctx context.Context, id, project string) error
+	List(ctx context.Context, project string, query *api.RESTGetListAPIKeysQueryParams) (api.RESTGetListAPIKeysData, error)
+	All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIAPIKey, error]
+	Get(ctx context.Context, id, project string) (api.RESTGetAPIKeyData, error)
+	GetData(ctx context.Context, id, project string) (api.APIAPIKey, error)
+	Rotate(ctx context.Context, id string, options RotateAPIKeyOptions) (api.APICreatedAPIKey, error)
+}
+
+// MessagesService is the method set of *Messages.
+type MessagesService interface {
+	Send(ctx context.Context, options SendMessageOptions) (api.RESTPostSendMessageData, error)
+	Get(ctx context.Context, id, project string) (api.RESTGetMessageData, error)
+	GetData(ctx context.Context, id, project string) (api.APIMessage, error)
+	List(ctx context.Context, project string, query *api.RESTGetListMessagesQueryParams) (api.RESTGetListMessagesData, error)
+	All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIMessage, error]
+	Cancel(ctx context.Context, id string, options CancelMessageOptions) (api.RESTPostCancelMessageData, error)
+}
+
+// ProjectsService is the method set of *Projects.
+type ProjectsService interface {
+	Get(ctx context.Context, id string) (api.RESTGetProjectData, error)
+	GetData(ctx context.Context, id string) (api.APIProject, error)
+	Update(ctx context.Context, options UpdateProjectOptions) (api.RESTPatchUpdateProjectData, error)
+	List(ctx context.Context, query *api.RESTGetListProjectsQueryParams) (api.RESTGetListProjectsData, error)
+	All(ctx context.Context, options *PaginateOptions) iter.Seq2[api.APIProject, error]
+}
+
+// PaymentsService is the method set of *Payments.
+type PaymentsService interface {
+	Balance(ctx context.Context, project string) (api.RESTGetBalanceData, error)
+	Usage(ctx context.Context, project string, query *api.RESTGetUsageQueryParams) (api.RESTGetUsageData, error)
+	Transactions(ctx context.Context, project string, query *api.RESTGetListTransactionsQueryParams) (api.RESTGetListTransactionsData, error)
+	AllTransactions(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APITransaction, error]
+	Invoices(ctx context.Context, project string, query *api.RESTGetListInvoicesQueryParams) (api.RESTGetListInvoicesData, error)
+	AllInvoices(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIInvoice, error]
+	Invoice(ctx context.Context, id, project string) (api.RESTGetInvoiceData, error)
+}
+
+var (
+	_ TemplatesService = (*Templates)(nil)
+	_ WebhooksService  = (*Webhooks)(nil)
+	_ APIKeysService   = (*APIKeys)(nil)
+	_ MessagesService  = (*Messages)(nil)
+	_ ProjectsService  = (*Projects)(nil)
+	_ PaymentsService  = (*Payments)(nil)
+)
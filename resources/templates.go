@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"iter"
 
 	"github.com/rewritetoday/golang/api"
 )
@@ -14,44 +15,111 @@ type Templates struct {
 // CreateTemplateOptions carries template creation input plus the target project ID.
 type CreateTemplateOptions struct {
 	Project string `json:"-"`
+	// IdempotencyKey overrides the key generated for this request.
+	IdempotencyKey string `json:"-"`
+	// SkipValidation sends the body without checking it locally first.
+	SkipValidation bool `json:"-"`
+	// StrictValidation also applies the unconfirmed name and length rules of
+	// api.RESTPostCreateTemplateBody.ValidateStrict.
+	StrictValidation bool `json:"-"`
 	api.RESTPostCreateTemplateBody
 }
 
 // UpdateTemplateOptions carries template update input plus the target project ID.
 type UpdateTemplateOptions struct {
 	Project string `json:"-"`
+	// SkipValidation sends the body without checking it locally first.
+	SkipValidation bool `json:"-"`
+	// StrictValidation also applies the unconfirmed length rule of
+	// api.RESTPatchUpdateTemplateBody.ValidateStrict.
+	StrictValidation bool `json:"-"`
 	api.RESTPatchUpdateTemplateBody
 }
 
 // Create creates a template for a project.
+//
+// The body is validated locally first and a *ValidationError is returned
+// without a request unless SkipValidation is set.
 func (r *Templates) Create(ctx context.Context, options CreateTemplateOptions) (api.RESTPostCreateTemplateData, error) {
 	var out api.RESTPostCreateTemplateData
-	err := r.Rest.Post(ctx, api.Routes.Templates.Create(options.Project), options.RESTPostCreateTemplateBody, &out, nil)
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	if !options.SkipValidation {
+		details := options.Validate()
+		if options.StrictValidation {
+			details = options.ValidateStrict()
+		}
+		if err := validated("Templates.Create", details); err != nil {
+			return out, err
+		}
+	}
+	err = r.Rest.Post(ctx, api.Routes.Templates.Create(project), options.RESTPostCreateTemplateBody, &out, callOptions("Templates.Create", options.IdempotencyKey))
 	return out, err
 }
 
 // Update updates a template by ID.
+//
+// The set fields are validated locally first unless SkipValidation is set.
 func (r *Templates) Update(ctx context.Context, id string, options UpdateTemplateOptions) (api.RESTPatchUpdateTemplateData, error) {
 	var out api.RESTPatchUpdateTemplateData
-	err := r.Rest.Patch(ctx, api.Routes.Templates.Update(options.Project, id), options.RESTPatchUpdateTemplateBody, &out, nil)
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	if !options.SkipValidation {
+		details := options.Validate()
+		if options.StrictValidation {
+			details = options.ValidateStrict()
+		}
+		if err := validated("Templates.Update", details); err != nil {
+			return out, err
+		}
+	}
+	err = r.Rest.Patch(ctx, api.Routes.Templates.Update(project, id), options.RESTPatchUpdateTemplateBody, &out, callOptions("Templates.Update", ""))
 	return out, err
 }
 
 // Delete deletes a template by ID.
 func (r *Templates) Delete(ctx context.Context, id, project string) error {
-	return r.Rest.Delete(ctx, api.Routes.Templates.Delete(project, id), nil, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return err
+	}
+	return r.Rest.Delete(ctx, api.Routes.Templates.Delete(project, id), nil, callOptions("Templates.Delete", ""))
 }
 
 // List lists templates for a project.
 func (r *Templates) List(ctx context.Context, project string, query *api.RESTGetListTemplatesQueryParams) (api.RESTGetListTemplatesData, error) {
 	var out api.RESTGetListTemplatesData
-	err := r.Rest.Get(ctx, api.Routes.Templates.List(project, query), &out, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Templates.List(project, query), &out, callOptions("Templates.List", ""))
 	return out, err
 }
 
+// All iterates over every template in a project, fetching pages as needed.
+func (r *Templates) All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APITemplate, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APITemplate], error) {
+		return r.List(ctx, project, query)
+	}, options)
+}
+
 // Get fetches a template by ID or unique name.
 func (r *Templates) Get(ctx context.Context, identifier, project string) (api.RESTGetTemplateData, error) {
 	var out api.RESTGetTemplateData
-	err := r.Rest.Get(ctx, api.Routes.Templates.Get(project, identifier), &out, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Templates.Get(project, identifier), &out, callOptions("Templates.Get", ""))
 	return out, err
 }
+
+// GetData fetches a template by ID or unique name and returns only the response data.
+func (r *Templates) GetData(ctx context.Context, identifier, project string) (api.APITemplate, error) {
+	return Data(r.Get(ctx, identifier, project))
+}
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"iter"
 
 	"github.com/rewritetoday/golang/api"
 )
@@ -14,6 +15,8 @@ type Webhooks struct {
 // CreateWebhookOptions carries webhook creation input plus the target project ID.
 type CreateWebhookOptions struct {
 	Project string `json:"-"`
+	// IdempotencyKey overrides the key generated for this request.
+	IdempotencyKey string `json:"-"`
 	api.RESTPostCreateWebhookBody
 }
 
@@ -26,32 +29,64 @@ type UpdateWebhookOptions struct {
 // Create creates a webhook for a project.
 func (r *Webhooks) Create(ctx context.Context, options CreateWebhookOptions) (api.RESTPostCreateWebhookData, error) {
 	var out api.RESTPostCreateWebhookData
-	err := r.Rest.Post(ctx, api.Routes.Webhooks.Create(options.Project), options.RESTPostCreateWebhookBody, &out, nil)
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Post(ctx, api.Routes.Webhooks.Create(project), options.RESTPostCreateWebhookBody, &out, callOptions("Webhooks.Create", options.IdempotencyKey))
 	return out, err
 }
 
 // Update updates a webhook by ID.
 func (r *Webhooks) Update(ctx context.Context, id string, options UpdateWebhookOptions) (api.RESTPatchUpdateWebhookData, error) {
 	var out api.RESTPatchUpdateWebhookData
-	err := r.Rest.Patch(ctx, api.Routes.Webhooks.Update(options.Project, id), options.RESTPatchUpdateWebhookBody, &out, nil)
+	project, err := r.project(options.Project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Patch(ctx, api.Routes.Webhooks.Update(project, id), options.RESTPatchUpdateWebhookBody, &out, callOptions("Webhooks.Update", ""))
 	return out, err
 }
 
 // Delete deletes a webhook by ID.
 func (r *Webhooks) Delete(ctx context.Context, id, project string) error {
-	return r.Rest.Delete(ctx, api.Routes.Webhooks.Delete(project, id), nil, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return err
+	}
+	return r.Rest.Delete(ctx, api.Routes.Webhooks.Delete(project, id), nil, callOptions("Webhooks.Delete", ""))
 }
 
 // List lists webhooks for a project.
 func (r *Webhooks) List(ctx context.Context, project string, query *api.RESTGetListWebhooksQueryParams) (api.RESTGetListWebhooksData, error) {
 	var out api.RESTGetListWebhooksData
-	err := r.Rest.Get(ctx, api.Routes.Webhooks.List(project, query), &out, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Webhooks.List(project, query), &out, callOptions("Webhooks.List", ""))
 	return out, err
 }
 
+// All iterates over every webhook in a project, fetching pages as needed.
+func (r *Webhooks) All(ctx context.Context, project string, options *PaginateOptions) iter.Seq2[api.APIWebhook, error] {
+	return Paginate(ctx, func(ctx context.Context, query *api.RESTCursorOptions) (api.APIResponse[[]api.APIWebhook], error) {
+		return r.List(ctx, project, query)
+	}, options)
+}
+
 // Get fetches a webhook by ID.
 func (r *Webhooks) Get(ctx context.Context, id, project string) (api.RESTGetWebhookData, error) {
 	var out api.RESTGetWebhookData
-	err := r.Rest.Get(ctx, api.Routes.Webhooks.Get(project, id), &out, nil)
+	project, err := r.project(project)
+	if err != nil {
+		return out, err
+	}
+	err = r.Rest.Get(ctx, api.Routes.Webhooks.Get(project, id), &out, callOptions("Webhooks.Get", ""))
 	return out, err
 }
+
+// GetData fetches a webhook by ID and returns only the response data.
+func (r *Webhooks) GetData(ctx context.Context, id, project string) (api.APIWebhook, error) {
+	return Data(r.Get(ctx, id, project))
+}
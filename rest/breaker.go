@@ -0,0 +1,251 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen matches CircuitOpenError through errors.Is.
+var ErrCircuitOpen = errors.New("Circuit breaker is open")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every request with ErrCircuitOpen.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a limited number of probe requests through.
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitScope selects how requests are grouped into circuits.
+type CircuitScope string
+
+const (
+	// CircuitScopeHost keeps one circuit per API host.
+	CircuitScopeHost CircuitScope = "host"
+	// CircuitScopeRoute keeps one circuit per method and route template.
+	CircuitScopeRoute CircuitScope = "route"
+)
+
+// CircuitBreakerOptions configures the circuit breaker.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens a circuit. Defaults to 5.
+	// Every attempt counts on its own, so one call that retries past 5xx responses
+	// can add several failures.
+	FailureThreshold int
+	// OpenTimeout is how long a circuit stays open before probing. Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of probes allowed, and the number of
+	// successes needed to close the circuit again. Defaults to 1.
+	HalfOpenRequests int
+	// Scope groups requests per host or per route. Defaults to CircuitScopeHost.
+	Scope CircuitScope
+	// IsFailure classifies an attempt. When nil, 5xx responses and transport errors count as failures.
+	// Attempts cut short by the caller's context being canceled or expiring are never counted.
+	IsFailure func(status int, err error) bool
+	// OnStateChange runs whenever a circuit changes state.
+	OnStateChange func(key string, from, to CircuitState)
+}
+
+// CircuitOpenError is returned when a request is rejected by an open circuit.
+type CircuitOpenError struct {
+	// Key identifies the circuit, e.g. the host or "GET /projects/{id}/templates".
+	Key string
+	// Until is when the circuit will let a probe through.
+	Until time.Time
+}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("Circuit breaker is open for %s", e.Key)
+}
+
+// Is lets errors.Is match ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type breakerOutcome int
+
+const (
+	breakerIgnored breakerOutcome = iota
+	breakerSuccess
+	breakerFailure
+)
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+type breaker struct {
+	options  CircuitBreakerOptions
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+func newBreaker(options *CircuitBreakerOptions) *breaker {
+	if options == nil {
+		return nil
+	}
+
+	resolved := *options
+	if resolved.FailureThreshold <= 0 {
+		resolved.FailureThreshold = 5
+	}
+	if resolved.OpenTimeout <= 0 {
+		resolved.OpenTimeout = 30 * time.Second
+	}
+	if resolved.HalfOpenRequests <= 0 {
+		resolved.HalfOpenRequests = 1
+	}
+	if resolved.Scope == "" {
+		resolved.Scope = CircuitScopeHost
+	}
+
+	return &breaker{options: resolved, circuits: make(map[string]*circuit)}
+}
+
+// admit checks the circuit for an attempt. The returned function records the outcome.
+func (b *breaker) admit(requestURL string, info RequestInfo) (func(breakerOutcome), error) {
+	if b == nil {
+		return func(breakerOutcome) {}, nil
+	}
+
+	key := info.Method + " " + info.RouteTemplate
+	if b.options.Scope != CircuitScopeRoute {
+		parsed, err := url.Parse(requestURL)
+		if err != nil {
+			return nil, err
+		}
+		key = parsed.Host
+	}
+
+	var changes []stateChange
+	defer func() { b.notify(changes) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[key] = c
+	}
+
+	probe := false
+	switch c.state {
+	case CircuitOpen:
+		until := c.openedAt.Add(b.options.OpenTimeout)
+		if time.Now().Before(until) {
+			return nil, &CircuitOpenError{Key: key, Until: until}
+		}
+		changes = b.transition(changes, key, c, CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if c.probes >= b.options.HalfOpenRequests {
+			return nil, &CircuitOpenError{Key: key, Until: time.Now().Add(b.options.OpenTimeout)}
+		}
+		c.probes++
+		probe = true
+	}
+
+	return func(outcome breakerOutcome) { b.record(key, c, probe, outcome) }, nil
+}
+
+func (b *breaker) record(key string, c *circuit, probe bool, outcome breakerOutcome) {
+	var changes []stateChange
+	defer func() { b.notify(changes) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		c.probes--
+	}
+
+	switch outcome {
+	case breakerSuccess:
+		c.failures = 0
+		if c.state == CircuitHalfOpen {
+			c.successes++
+			if c.successes >= b.options.HalfOpenRequests {
+				changes = b.transition(changes, key, c, CircuitClosed)
+			}
+		}
+	case breakerFailure:
+		c.failures++
+		if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= b.options.FailureThreshold) {
+			changes = b.transition(changes, key, c, CircuitOpen)
+		}
+	}
+}
+
+type stateChange struct {
+	key      string
+	from, to CircuitState
+}
+
+// transition must be called with b.mu held. Callbacks run later through notify.
+func (b *breaker) transition(changes []stateChange, key string, c *circuit, to CircuitState) []stateChange {
+	from := c.state
+	if from == to {
+		return changes
+	}
+
+	c.state = to
+	c.successes = 0
+	if to == CircuitOpen {
+		c.openedAt = time.Now()
+	}
+	if to == CircuitClosed {
+		c.failures = 0
+	}
+	return append(changes, stateChange{key: key, from: from, to: to})
+}
+
+func (b *breaker) notify(changes []stateChange) {
+	if b.options.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.options.OnStateChange(change.key, change.from, change.to)
+	}
+}
+
+// outcome classifies an attempt. ctx is the caller's context, not the per-attempt timeout.
+func (b *breaker) outcome(ctx context.Context, status int, err error) breakerOutcome {
+	if b == nil {
+		return breakerIgnored
+	}
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled)) {
+		return breakerIgnored
+	}
+	if b.options.IsFailure != nil {
+		if b.options.IsFailure(status, err) {
+			return breakerFailure
+		}
+		return breakerSuccess
+	}
+	if err != nil {
+		if isTransportError(err) {
+			return breakerFailure
+		}
+		return breakerIgnored
+	}
+	if status >= http.StatusInternalServerError {
+		return breakerFailure
+	}
+	return breakerSuccess
+}
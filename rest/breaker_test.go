@@ -0,0 +1,174 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	var healthy atomic.Bool
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var changes []string
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+		CircuitBreaker: &CircuitBreakerOptions{
+			FailureThreshold: 2,
+			OpenTimeout:      20 * time.Millisecond,
+			OnStateChange: func(_ string, from, to CircuitState) {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, string(from)+">"+string(to))
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	err = client.Get(context.Background(), "/projects/1", nil, nil)
+	var openErr *CircuitOpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("expected the breaker to stop after 2 attempts, got %d", attempts.Load())
+	}
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected an open circuit to fail fast, got %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Fatalf("expected no attempt while open, got %d", attempts.Load())
+	}
+
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(changes, ",") != "closed>open,open>half-open,half-open>closed" {
+		t.Fatalf("unexpected state changes: %v", changes)
+	}
+}
+
+func TestCircuitBreakerIgnoresCallerContextErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Options{
+		Auth:           "rw",
+		BaseURL:        server.URL,
+		Retry:          &RetryOptions{Max: 1, Delay: func(int) time.Duration { return 0 }},
+		CircuitBreaker: &CircuitBreakerOptions{FailureThreshold: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	for range 3 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := client.Get(ctx, "/projects/1?slow=1", nil, nil)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected the caller deadline, got %v", err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.Get(ctx, "/projects/1", nil, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a canceled context, got %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); err != nil {
+		t.Fatalf("expected the circuit to stay closed, got %v", err)
+	}
+
+	err = client.Get(context.Background(), "/projects/1?slow=1", nil, &FetchOptions{Timeout: 10 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected the client timeout to fail the request")
+	}
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the client's own timeout to count as a failure, got %v", err)
+	}
+}
+
+func TestCircuitBreakerAdmitsProbesAfterTheLimiter(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Options{
+		Auth:           "rw",
+		BaseURL:        server.URL,
+		Retry:          &RetryOptions{Max: 1, Delay: func(int) time.Duration { return 0 }},
+		Limiter:        &LimiterOptions{MaxInFlight: 1, DisableAdaptive: true},
+		CircuitBreaker: &CircuitBreakerOptions{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the circuit to open, got %v", err)
+	}
+	healthy.Store(true)
+	time.Sleep(20 * time.Millisecond)
+
+	// Hold the only slot so the next requests queue in the limiter.
+	release, err := client.limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected acquire error: %v", err)
+	}
+	probe := make(chan error, 1)
+	go func() { probe <- client.Get(context.Background(), "/projects/1", nil, nil) }()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Get(ctx, "/projects/1", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a queued request to wait instead of taking the probe, got %v", err)
+	}
+
+	release()
+	if err := <-probe; err != nil {
+		t.Fatalf("expected the probe to run once it leaves the queue, got %v", err)
+	}
+}
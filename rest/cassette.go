@@ -0,0 +1,273 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// ErrCassetteMiss is returned in replay mode when no recorded interaction matches a request.
+var ErrCassetteMiss = errors.New("No cassette interaction matches the request")
+
+// CassetteMode selects whether a cassette records or replays interactions.
+type CassetteMode string
+
+const (
+	// CassetteAuto replays the cassette when the file exists and records it otherwise.
+	CassetteAuto CassetteMode = ""
+	// CassetteRecord sends real requests and overwrites the cassette with them.
+	CassetteRecord CassetteMode = "record"
+	// CassetteReplay serves recorded responses and never touches the network.
+	CassetteReplay CassetteMode = "replay"
+)
+
+// CassetteOptions configures HTTP record/replay.
+//
+// Interactions are matched on method, route template and JSON body, so IDs in
+// routes and key order in bodies do not matter. Each recorded interaction is
+// served once, in recording order.
+type CassetteOptions struct {
+	// Path is the cassette file.
+	Path string
+	// Mode defaults to CassetteAuto.
+	Mode CassetteMode
+	// ScrubFields lists JSON body fields to scrub, in addition to key, secret,
+	// token, password, authorization and Options.RedactFields.
+	ScrubFields []string
+	// ScrubHeaders lists headers to scrub, in addition to Authorization,
+	// Cookie and Set-Cookie.
+	ScrubHeaders []string
+}
+
+// Cassette is the on-disk format of recorded interactions.
+type Cassette struct {
+	Version      int           `json:"version"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed request of an Interaction.
+type RecordedRequest struct {
+	Method        string      `json:"method"`
+	Route         string      `json:"route"`
+	RouteTemplate string      `json:"routeTemplate"`
+	Headers       http.Header `json:"headers,omitempty"`
+	Body          string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response of an Interaction.
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+const cassetteVersion = 1
+
+// defaultScrubHeaders are always scrubbed from recorded interactions.
+var defaultScrubHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+type cassette struct {
+	client  *Client
+	options CassetteOptions
+	replay  bool
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+func newCassette(client *Client, options *CassetteOptions) (*cassette, error) {
+	if options == nil {
+		return nil, nil
+	}
+	if options.Path == "" {
+		return nil, errors.New("Expected a path for the cassette")
+	}
+
+	c := &cassette{client: client, options: *options}
+	switch options.Mode {
+	case CassetteRecord:
+	case CassetteReplay:
+		c.replay = true
+	case CassetteAuto:
+		_, err := os.Stat(options.Path)
+		c.replay = err == nil
+	default:
+		return nil, fmt.Errorf("Unknown cassette mode %q", options.Mode)
+	}
+
+	if c.replay {
+		data, err := os.ReadFile(options.Path)
+		if err != nil {
+			return nil, err
+		}
+		var loaded Cassette
+		if err := json.Unmarshal(data, &loaded); err != nil {
+			return nil, fmt.Errorf("Invalid cassette %s: %w", options.Path, err)
+		}
+		c.interactions = loaded.Interactions
+		c.used = make([]bool, len(loaded.Interactions))
+	}
+	return c, nil
+}
+
+// wrap returns the handler that records or replays around next.
+func (c *cassette) wrap(next Handler) Handler {
+	if c == nil {
+		return next
+	}
+	return func(req *http.Request) (*http.Response, error) {
+		var body []byte
+		if req.Body != nil {
+			var err error
+			body, err = io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		info, _ := RequestInfoFromContext(req.Context())
+		recorded := RecordedRequest{
+			Method:        req.Method,
+			Route:         info.Route,
+			RouteTemplate: info.RouteTemplate,
+			Headers:       c.scrubHeaders(req.Header),
+			Body:          string(c.scrubBody(body)),
+		}
+		if req.URL.RawQuery != "" {
+			recorded.Route += "?" + req.URL.RawQuery
+		}
+
+		if c.replay {
+			return c.serve(req, recorded)
+		}
+		return c.record(req, recorded, next)
+	}
+}
+
+func (c *cassette) serve(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		c.used[i] = true
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode: interaction.Response.Status,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     interaction.Response.Headers.Clone(),
+			Body:       io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			Request:    req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrCassetteMiss, recorded.Method, recorded.RouteTemplate)
+}
+
+func (c *cassette) record(req *http.Request, recorded RecordedRequest, next Handler) (*http.Response, error) {
+	res, err := next(req)
+	if err != nil {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			Status:  res.StatusCode,
+			Headers: c.scrubHeaders(res.Header),
+			Body:    string(c.scrubBody(body)),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// save must be called with c.mu held. It writes through a temporary file so a
+// crash never leaves a truncated cassette behind.
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(Cassette{Version: cassetteVersion, Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(c.options.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(c.options.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.options.Path)
+}
+
+func (c *cassette) scrubBody(body []byte) []byte {
+	return c.client.redactJSON(body, c.options.ScrubFields)
+}
+
+func (c *cassette) scrubHeaders(headers http.Header) http.Header {
+	out := headers.Clone()
+	for _, name := range slices.Concat(defaultScrubHeaders, c.options.ScrubHeaders) {
+		if out.Get(name) != "" {
+			out.Set(name, redacted)
+		}
+	}
+	out.Del(idempotencyKeyHeader)
+	for name, values := range out {
+		for i, value := range values {
+			values[i] = c.client.redactString(value)
+		}
+		out[name] = values
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func matches(recorded, request RecordedRequest) bool {
+	return recorded.Method == request.Method &&
+		recorded.RouteTemplate == request.RouteTemplate &&
+		recorded.Body == request.Body
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCassetteRecordsAndReplays(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"ok":true,"data":[{"id":"7","name":"backend"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"7","key":"rw_live_created"}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "api_keys.json")
+	newClient := func(mode CassetteMode) *Client {
+		client, err := New(Options{
+			Auth:     "rw_live_secret",
+			BaseURL:  server.URL,
+			Cassette: &CassetteOptions{Path: path, Mode: mode},
+			Retry:    &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+		})
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %v", err)
+		}
+		return client
+	}
+
+	type key struct {
+		Data struct {
+			ID  string `json:"id"`
+			Key string `json:"key"`
+		} `json:"data"`
+	}
+	body := map[string]string{"name": "backend"}
+	ctx := context.Background()
+
+	recorder := newClient(CassetteAuto)
+	var created key
+	if err := recorder.Post(ctx, "/projects/1/api-keys", body, &created, nil); err != nil {
+		t.Fatalf("unexpected record error: %v", err)
+	}
+	if created.Data.Key != "rw_live_created" {
+		t.Fatalf("expected the live response while recording, got %+v", created)
+	}
+	if err := recorder.Get(ctx, "/projects/1/api-keys", nil, nil); err != nil {
+		t.Fatalf("unexpected record error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected a cassette file: %v", err)
+	}
+	for _, secret := range []string{"rw_live_secret", "rw_live_created", "Idempotency-Key"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("cassette leaked %q: %s", secret, data)
+		}
+	}
+
+	server.Close()
+	hits.Store(0)
+
+	player := newClient(CassetteAuto)
+	var replayed key
+	if err := player.Post(ctx, "/projects/2/api-keys", map[string]string{"name": "backend"}, &replayed, nil); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+	if replayed.Data.ID != "7" || replayed.Data.Key != redacted {
+		t.Fatalf("unexpected replayed key: %+v", replayed)
+	}
+	if err := player.Get(ctx, "/projects/1/api-keys", nil, nil); err != nil {
+		t.Fatalf("unexpected replay error: %v", err)
+	}
+
+	err = player.Get(ctx, "/projects/1/api-keys", nil, nil)
+	if !errors.Is(err, ErrCassetteMiss) {
+		t.Fatalf("expected ErrCassetteMiss once interactions are used up, got %v", err)
+	}
+	if hits.Load() != 0 {
+		t.Fatalf("expected replay to stay offline, got %d requests", hits.Load())
+	}
+}
+
+func TestCassetteReplayRequiresFile(t *testing.T) {
+	_, err := New(Options{Cassette: &CassetteOptions{Path: filepath.Join(t.TempDir(), "missing.json"), Mode: CassetteReplay}})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a missing cassette error, got %v", err)
+	}
+}
@@ -5,13 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
-
-	"github.com/go-resty/resty/v2"
 )
 
 const (
@@ -19,6 +19,9 @@ const (
 	fiveSeconds    = 5 * time.Second
 	baseDelay      = 300 * time.Millisecond
 	maxDelay       = 10 * time.Second
+
+	requestIDHeader      = "X-Request-Id"
+	idempotencyKeyHeader = "Idempotency-Key"
 )
 
 var retryableStatus = map[int]struct{}{
@@ -35,7 +38,10 @@ var retryableStatus = map[int]struct{}{
 type Client struct {
 	options Options
 	headers map[string]string
-	client  *resty.Client
+	client  *http.Client
+	handler Handler
+	limiter *limiter
+	breaker *breaker
 }
 
 // New creates a REST client from an auth string or Options struct.
@@ -61,11 +67,23 @@ func New(options any) (*Client, error) {
 	}
 	headers["Authorization"] = "Bearer " + resolved.Auth
 
-	return &Client{
+	client := newHTTPClient(resolved)
+
+	c := &Client{
 		options: resolved,
 		headers: headers,
-		client:  resty.New(),
-	}, nil
+		client:  client,
+		limiter: newLimiter(resolved.Limiter, maxRetryAfter(resolved.Retry)),
+		breaker: newBreaker(resolved.CircuitBreaker),
+	}
+
+	cassette, err := newCassette(c, resolved.Cassette)
+	if err != nil {
+		return nil, err
+	}
+	c.handler = chain(cassette.wrap(client.Do), resolved.Middleware)
+
+	return c, nil
 }
 
 // SetAuth updates the authorization token.
@@ -79,7 +97,7 @@ func (c *Client) SetAuth(authorization string) *Client {
 func (c *Client) Get(ctx context.Context, route string, out any, options *FetchOptions) error {
 	opts := cloneFetchOptions(options)
 	opts.method = "GET"
-	return c.fetch(ctx, route, out, opts, 0)
+	return c.call(ctx, route, out, opts)
 }
 
 // Post executes a POST request.
@@ -88,21 +106,24 @@ func (c *Client) Post(ctx context.Context, route string, data any, out any, opti
 	opts.method = "POST"
 	opts.data = data
 	opts.hasData = true
-	return c.fetch(ctx, route, out, opts, 0)
+	if opts.IdempotencyKey == "" {
+		opts.IdempotencyKey = NewIdempotencyKey()
+	}
+	return c.call(ctx, route, out, opts)
 }
 
 // Delete executes a DELETE request.
 func (c *Client) Delete(ctx context.Context, route string, out any, options *FetchOptions) error {
 	opts := cloneFetchOptions(options)
 	opts.method = "DELETE"
-	return c.fetch(ctx, route, out, opts, 0)
+	return c.call(ctx, route, out, opts)
 }
 
 // Put executes a PUT request.
 func (c *Client) Put(ctx context.Context, route string, out any, options *FetchOptions) error {
 	opts := cloneFetchOptions(options)
 	opts.method = "PUT"
-	return c.fetch(ctx, route, out, opts, 0)
+	return c.call(ctx, route, out, opts)
 }
 
 // Patch executes a PATCH request.
@@ -111,31 +132,49 @@ func (c *Client) Patch(ctx context.Context, route string, data any, out any, opt
 	opts.method = "PATCH"
 	opts.data = data
 	opts.hasData = true
-	return c.fetch(ctx, route, out, opts, 0)
+	return c.call(ctx, route, out, opts)
 }
 
 func (c *Client) fetch(ctx context.Context, route string, out any, options FetchOptions, attempt int) error {
-	response, err := c.execute(ctx, route, options)
+	start := time.Now()
+	response, err := c.execute(ctx, route, options, attempt)
+	if options.state != nil {
+		options.state.attempts = attempt + 1
+		if response != nil {
+			options.state.status = response.status
+		}
+	}
+	c.logAttempt(ctx, options, route, attempt, response, err, time.Since(start))
 	if err != nil {
-		return err
+		return c.handleError(ctx, route, out, options, attempt, nil, err)
+	}
+
+	if response.isError() {
+		return c.handleError(ctx, route, out, options, attempt, response, nil)
+	}
+
+	if len(response.body) == 0 {
+		return nil
 	}
 
-	if response.IsError() {
-		return c.handleError(ctx, route, out, options, attempt, response)
+	if isFailedEnvelope(response.body) {
+		err := newHTTPError(response, options.method, "")
+		c.logFailure(ctx, options, route, attempt, err)
+		return err
 	}
 
-	if out == nil || len(response.Body()) == 0 {
+	if out == nil {
 		return nil
 	}
 
-	if err := json.Unmarshal(response.Body(), out); err != nil {
+	if err := json.Unmarshal(response.body, out); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Client) execute(ctx context.Context, route string, options FetchOptions) (*resty.Response, error) {
+func (c *Client) execute(ctx context.Context, route string, options FetchOptions, attempt int) (*response, error) {
 	requestURL, err := CreateURL(route, options.Query, c.options.BaseURL)
 	if err != nil {
 		return nil, err
@@ -149,32 +188,121 @@ func (c *Client) execute(ctx context.Context, route string, options FetchOptions
 		timeout = fiveSeconds
 	}
 
+	info := RequestInfo{
+		Method:  options.method,
+		Route:   routePath(route),
+		Attempt: attempt,
+	}
+	if options.state != nil {
+		info.Operation = options.state.info.Operation
+		info.RouteTemplate = options.state.info.RouteTemplate
+	}
+
+	var body io.Reader
+	isJSON := false
+	if options.hasData {
+		body, isJSON, err = encodeBody(options.data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	requestCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	requestCtx = withRequestInfo(requestCtx, info)
 
-	req := c.client.R().SetContext(requestCtx)
+	req, err := http.NewRequestWithContext(requestCtx, options.method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if isJSON {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	for k, v := range c.headers {
-		req.SetHeader(k, v)
+		req.Header.Set(k, v)
 	}
 	for k, v := range options.Headers {
-		req.SetHeader(k, v)
+		req.Header.Set(k, v)
 	}
-	if options.hasData {
-		req.SetBody(options.data)
+	if options.IdempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, options.IdempotencyKey)
+	}
+
+	// Admit after waiting on the limiter so a half-open probe is not held up
+	// in the queue while other requests are rejected.
+	done, err := c.breaker.admit(requestURL, info)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.handler(req)
+	if err == nil && res == nil {
+		err = errors.New("Middleware returned no response")
+	}
+	if err != nil {
+		done(c.breaker.outcome(ctx, 0, err))
+		return nil, err
 	}
+	defer res.Body.Close()
+	c.limiter.observe(res.StatusCode, res.Header)
 
-	return req.Execute(options.method, requestURL)
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		done(c.breaker.outcome(ctx, 0, err))
+		return nil, err
+	}
+	done(c.breaker.outcome(ctx, res.StatusCode, nil))
+
+	return &response{
+		status: res.StatusCode,
+		header: res.Header,
+		body:   data,
+		url:    requestURL,
+	}, nil
 }
 
-func (c *Client) handleError(ctx context.Context, route string, out any, options FetchOptions, attempt int, response *resty.Response) error {
-	status := response.StatusCode()
-	if !isRetryableStatus(status) {
-		return &HTTPError{
-			Message: readErrorMessage(response.Body()),
-			Status:  status,
-			URL:     response.Request.URL,
-			Method:  options.method,
+func (c *Client) handleError(ctx context.Context, route string, out any, options FetchOptions, attempt int, response *response, cause error) error {
+	retry := HandleErrorOptions{
+		Method:  options.method,
+		Route:   route,
+		Attempt: attempt,
+		Err:     cause,
+		Options: options,
+	}
+
+	now := time.Now()
+	if cause != nil {
+		// The caller's context is done; retrying cannot succeed.
+		if ctx.Err() != nil {
+			return cause
 		}
+		retry.Reason = RetryReasonTransport
+	} else {
+		retry.Reason = RetryReasonStatus
+		retry.Response = newResponseMeta(response.status, response.url, response.header, now)
+	}
+
+	fail := func(message string) error {
+		err := cause
+		if err == nil {
+			err = newHTTPError(response, options.method, message)
+		}
+		c.logFailure(ctx, options, route, attempt, err)
+		return err
+	}
+
+	shouldRetry := DefaultShouldRetry
+	if c.options.Retry != nil && c.options.Retry.ShouldRetry != nil {
+		shouldRetry = c.options.Retry.ShouldRetry
+	}
+	if !shouldRetry(retry) {
+		return fail("")
 	}
 
 	maxRetries := 3
@@ -182,31 +310,28 @@ func (c *Client) handleError(ctx context.Context, route string, out any, options
 		maxRetries = c.options.Retry.Max
 	}
 	if attempt >= maxRetries {
-		return &HTTPError{
-			Message: "Max retries reached",
-			Status:  status,
-			URL:     response.Request.URL,
-			Method:  options.method,
-		}
-	}
-
-	if c.options.Retry != nil && c.options.Retry.OnRetry != nil {
-		c.options.Retry.OnRetry(HandleErrorOptions{
-			Method:  options.method,
-			Route:   route,
-			Attempt: attempt,
-			Response: &ResponseMeta{
-				Status: status,
-				URL:    response.Request.URL,
-			},
-			Options: options,
-		})
+		return fail("Max retries reached")
 	}
 
 	delay := Backoff(attempt)
 	if c.options.Retry != nil && c.options.Retry.Delay != nil {
 		delay = c.options.Retry.Delay(attempt)
 	}
+	if retry.Response != nil {
+		if wait, ok := retry.Response.serverDelay(now); ok {
+			if maxWait := maxRetryAfter(c.options.Retry); maxWait > 0 && wait > maxWait {
+				return fail("")
+			}
+			delay = wait
+		}
+	}
+	retry.Delay = delay
+
+	c.logRetry(ctx, retry)
+	if c.options.Retry != nil && c.options.Retry.OnRetry != nil {
+		c.options.Retry.OnRetry(retry)
+	}
+
 	if err := sleepWithContext(ctx, delay); err != nil {
 		return err
 	}
@@ -321,25 +446,20 @@ func sleepWithContext(ctx context.Context, delay time.Duration) error {
 	}
 }
 
-func readErrorMessage(body []byte) string {
-	if len(body) == 0 {
-		return "Request failed"
-	}
-
-	var parsed struct {
-		Error any `json:"error"`
+func newHTTPError(response *response, method, message string) *HTTPError {
+	parsed := parseErrorBody(response.body)
+	if message == "" {
+		message = parsed.message
+	}
+	return &HTTPError{
+		Message:   message,
+		Status:    response.status,
+		URL:       response.url,
+		Method:    method,
+		Code:      parsed.code,
+		Errors:    parsed.validation,
+		Body:      response.body,
+		Headers:   response.header,
+		RequestID: response.header.Get(requestIDHeader),
 	}
-	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error != nil {
-		switch v := parsed.Error.(type) {
-		case string:
-			return v
-		case map[string]any:
-			if message, ok := v["message"].(string); ok && message != "" {
-				return message
-			}
-		}
-		return fmt.Sprintf("%v", parsed.Error)
-	}
-
-	return string(body)
 }
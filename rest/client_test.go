@@ -1,9 +1,17 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -78,3 +86,360 @@ func TestCreateURLAvoidsDoubleV1(t *testing.T) {
 		t.Fatalf("unexpected URL: %s", url)
 	}
 }
+
+func TestHTTPErrorKeepsEnvelopeDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req_1")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"code":"INVALID_BODY","message":"Invalid body","errors":{"message":"Invalid body","detailed":{"name":"Required"}}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Options{Auth: "rw", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	err = client.Post(context.Background(), "/projects/1/templates", map[string]string{}, nil, nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("expected HTTPError, got %T", err)
+	}
+	if httpErr.Message != "Invalid body" || httpErr.Code != "INVALID_BODY" || httpErr.RequestID != "req_1" {
+		t.Fatalf("unexpected error: %+v", httpErr)
+	}
+	if httpErr.FieldErrors()["name"] != "Required" {
+		t.Fatalf("unexpected field errors: %#v", httpErr.FieldErrors())
+	}
+	if !errors.Is(err, ErrValidation) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("unexpected sentinel matching for %v", err)
+	}
+}
+
+func TestHTTPErrorSentinels(t *testing.T) {
+	cases := map[int]error{
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusTooManyRequests: ErrRateLimited,
+	}
+	for status, sentinel := range cases {
+		err := error(&HTTPError{Status: status})
+		if !errors.Is(err, sentinel) {
+			t.Fatalf("expected %d to match %v", status, sentinel)
+		}
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var retry HandleErrorOptions
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry: &RetryOptions{
+			Delay:   func(int) time.Duration { return time.Hour },
+			OnRetry: func(options HandleErrorOptions) { retry = options },
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if retry.Delay != 0 || !retry.Response.HasRetryAfter {
+		t.Fatalf("expected Retry-After to override delay, got %+v", retry)
+	}
+	if retry.Response.RateLimit == nil || retry.Response.RateLimit.Remaining != 0 {
+		t.Fatalf("unexpected rate limit: %+v", retry.Response.RateLimit)
+	}
+}
+
+func TestRetryAfterAboveCapFailsFast(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(Options{Auth: "rw", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	err = client.Get(context.Background(), "/projects/1", nil, nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 HTTPError, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 2, 19, 20, 0, 0, 0, time.UTC)
+
+	if wait, ok := parseRetryAfter("2", now); !ok || wait != 2*time.Second {
+		t.Fatalf("unexpected seconds parse: %v %v", wait, ok)
+	}
+	if wait, ok := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now); !ok || wait != 30*time.Second {
+		t.Fatalf("unexpected date parse: %v %v", wait, ok)
+	}
+	for _, value := range []string{"soon", "NaN", "Inf", "1e300", "-5", "1.5", "+3"} {
+		if _, ok := parseRetryAfter(value, now); ok {
+			t.Fatalf("expected %q to be ignored", value)
+		}
+	}
+	if wait, ok := parseRetryAfter("99999999999999999999", now); !ok || wait != time.Duration(math.MaxInt64) {
+		t.Fatalf("expected a huge delay to saturate, got %v %v", wait, ok)
+	}
+}
+
+func newFlakyServer(t *testing.T, attempts *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatalf("hijack: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+}
+
+func TestRetryTransportErrorForIdempotentRequest(t *testing.T) {
+	var attempts atomic.Int32
+	server := newFlakyServer(t, &attempts)
+	defer server.Close()
+
+	var reason RetryReason
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry: &RetryOptions{
+			Delay:   func(int) time.Duration { return 0 },
+			OnRetry: func(options HandleErrorOptions) { reason = options.Reason },
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/projects/1", nil, nil); err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+	if attempts.Load() != 2 || reason != RetryReasonTransport {
+		t.Fatalf("expected a transport retry, got attempts=%d reason=%q", attempts.Load(), reason)
+	}
+}
+
+func TestNoTransportRetryForPatch(t *testing.T) {
+	var attempts atomic.Int32
+	server := newFlakyServer(t, &attempts)
+	defer server.Close()
+
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Patch(context.Background(), "/projects/1/templates/1", map[string]string{}, nil, nil); err == nil {
+		t.Fatal("expected transport error")
+	}
+	if attempts.Load() != 1 {
+		t.Fatalf("expected a single attempt, got %d", attempts.Load())
+	}
+}
+
+func TestPostReusesIdempotencyKeyAcrossRetries(t *testing.T) {
+	var keys []string
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Post(context.Background(), "/projects/1/templates", map[string]string{}, nil, nil); err != nil {
+		t.Fatalf("unexpected post error: %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected the same generated key on every attempt, got %q", keys)
+	}
+
+	keys = nil
+	if err := client.Post(context.Background(), "/projects/1/templates", map[string]string{}, nil, &FetchOptions{IdempotencyKey: "order-42"}); err != nil {
+		t.Fatalf("unexpected post error: %v", err)
+	}
+	if len(keys) != 1 || keys[0] != "order-42" {
+		t.Fatalf("expected caller key, got %q", keys)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestCustomTransport(t *testing.T) {
+	var seen *http.Request
+	client, err := New(Options{
+		Auth: "rw",
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			seen = r
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"ok":true,"data":{"id":"1"}}`)),
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	var out api.RESTPostCreateWebhookData
+	if err := client.Post(context.Background(), "/projects/1/webhooks", api.RESTPostCreateWebhookBody{Endpoint: "https://example.com"}, &out, nil); err != nil {
+		t.Fatalf("unexpected post error: %v", err)
+	}
+	if out.Data.ID != "1" {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+	if seen == nil || seen.URL.String() != "https://api.rewritetoday.com/v1/projects/1/webhooks" {
+		t.Fatalf("unexpected request: %+v", seen)
+	}
+	if seen.Header.Get("Content-Type") != "application/json" || seen.Header.Get("Authorization") != "Bearer rw" {
+		t.Fatalf("unexpected headers: %v", seen.Header)
+	}
+}
+
+func TestMiddlewareSeesEveryAttempt(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if r.Header.Get("X-Signed") != "yes" {
+			t.Fatalf("missing middleware header")
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var seen []string
+	client, err := New(Options{
+		Auth:    "rw",
+		BaseURL: server.URL,
+		Retry:   &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+		Middleware: []Middleware{
+			func(next Handler) Handler {
+				return func(req *http.Request) (*http.Response, error) {
+					req.Header.Set("X-Signed", "yes")
+					res, err := next(req)
+					info, _ := RequestInfoFromContext(req.Context())
+					if err == nil {
+						seen = append(seen, fmt.Sprintf("%s %s #%d -> %d", info.Method, info.Route, info.Attempt, res.StatusCode))
+					}
+					return res, err
+				}
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	if err := client.Get(context.Background(), "/projects/1/webhooks?limit=15", nil, nil); err != nil {
+		t.Fatalf("unexpected get error: %v", err)
+	}
+
+	want := "GET /projects/1/webhooks #0 -> 503,GET /projects/1/webhooks #1 -> 200"
+	if strings.Join(seen, ",") != want {
+		t.Fatalf("unexpected middleware observations: %v", seen)
+	}
+}
+
+func TestLoggerRedactsSecrets(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"data":{"id":"1","key":"rw_live_created","createdAt":"2026-02-19T20:01:09.000Z"}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client, err := New(Options{
+		Auth:         "rw_live_secret",
+		BaseURL:      server.URL,
+		Logger:       slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogBodies:    true,
+		RedactFields: []string{"name"},
+		Retry:        &RetryOptions{Delay: func(int) time.Duration { return 0 }},
+	})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	body := map[string]string{"name": "backend-prod", "note": "uses rw_live_secret"}
+	if err := client.Post(context.Background(), "/projects/1/api-keys", body, nil, nil); err != nil {
+		t.Fatalf("unexpected post error: %v", err)
+	}
+
+	output := logs.String()
+	for _, secret := range []string{"rw_live_secret", "rw_live_created", "backend-prod"} {
+		if strings.Contains(output, secret) {
+			t.Fatalf("log output leaked %q: %s", secret, output)
+		}
+	}
+	if !strings.Contains(output, `"msg":"rewrite retry"`) || !strings.Contains(output, `"route":"/projects/1/api-keys"`) {
+		t.Fatalf("expected retry log, got: %s", output)
+	}
+}
@@ -1,6 +1,27 @@
 package rest
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Sentinel errors matched by HTTPError through errors.Is.
+var (
+	// ErrUnauthorized matches 401 responses.
+	ErrUnauthorized = errors.New("Unauthorized")
+	// ErrForbidden matches 403 responses.
+	ErrForbidden = errors.New("Forbidden")
+	// ErrNotFound matches 404 responses.
+	ErrNotFound = errors.New("Not found")
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = errors.New("Rate limited")
+	// ErrValidation matches 422 responses and any response carrying validation details.
+	ErrValidation = errors.New("Validation failed")
+)
 
 // HTTPError is returned when the API responds with a non-success status.
 type HTTPError struct {
@@ -12,6 +33,16 @@ type HTTPError struct {
 	URL string
 	// Method is the HTTP method used in the request.
 	Method string
+	// Code is the machine-readable API error code, when present.
+	Code string
+	// Errors carries field-level validation details, when present.
+	Errors *api.APIValidationError
+	// Body is the raw response body.
+	Body []byte
+	// Headers are the response headers.
+	Headers http.Header
+	// RequestID is the server-assigned request ID, when present.
+	RequestID string
 }
 
 // Error implements the error interface.
@@ -24,3 +55,90 @@ func (e *HTTPError) Error() string {
 	}
 	return fmt.Sprintf("HTTPError(%d)", e.Status)
 }
+
+// Is lets errors.Is match HTTPError against the package sentinels.
+func (e *HTTPError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	switch target {
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.Status == http.StatusUnprocessableEntity || e.Errors != nil
+	}
+	return false
+}
+
+// FieldErrors returns the field-level validation details, or nil.
+func (e *HTTPError) FieldErrors() map[string]any {
+	if e == nil || e.Errors == nil {
+		return nil
+	}
+	return e.Errors.Detailed
+}
+
+type errorBody struct {
+	message    string
+	code       string
+	validation *api.APIValidationError
+}
+
+func parseErrorBody(body []byte) errorBody {
+	if len(body) == 0 {
+		return errorBody{message: "Request failed"}
+	}
+
+	var parsed struct {
+		Error   any                     `json:"error"`
+		Message string                  `json:"message"`
+		Code    string                  `json:"code"`
+		Errors  *api.APIValidationError `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return errorBody{message: string(body)}
+	}
+
+	out := errorBody{message: parsed.Message, code: parsed.Code, validation: parsed.Errors}
+	switch v := parsed.Error.(type) {
+	case nil:
+	case string:
+		out.message = v
+	case map[string]any:
+		if message, ok := v["message"].(string); ok && message != "" {
+			out.message = message
+		} else {
+			out.message = fmt.Sprintf("%v", v)
+		}
+		if code, ok := v["code"].(string); ok && out.code == "" {
+			out.code = code
+		}
+	default:
+		out.message = fmt.Sprintf("%v", v)
+	}
+
+	if out.message == "" && out.validation != nil {
+		out.message = out.validation.Message
+	}
+	if out.message == "" {
+		out.message = string(body)
+	}
+	return out
+}
+
+// isFailedEnvelope reports whether body is an API envelope with "ok": false.
+func isFailedEnvelope(body []byte) bool {
+	var parsed struct {
+		OK *bool `json:"ok"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil || parsed.OK == nil {
+		return false
+	}
+	return !*parsed.OK
+}
@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrLimiterDeadline is returned when waiting for the client-side limiter
+// would outlive the request context deadline.
+var ErrLimiterDeadline = errors.New("Rate limiter wait would exceed the context deadline")
+
+// LimiterOptions configures client-side throttling.
+type LimiterOptions struct {
+	// RequestsPerSecond is the steady token-bucket rate. Zero disables rate limiting.
+	RequestsPerSecond float64
+	// Burst is the bucket size. When zero, RequestsPerSecond rounded up is used.
+	Burst int
+	// MaxInFlight caps concurrent HTTP attempts. Zero means unlimited.
+	MaxInFlight int
+	// DisableAdaptive stops the limiter from pausing every request when the
+	// server reports an exhausted quota through Retry-After or X-RateLimit-*.
+	// The pause never exceeds RetryOptions.MaxRetryAfter.
+	DisableAdaptive bool
+}
+
+// limiter combines a token bucket, an in-flight semaphore and a server-driven pause.
+type limiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	adaptive    bool
+	maxPause    time.Duration
+	slots       chan struct{}
+}
+
+// newLimiter returns nil when options is nil. A non-positive maxPause leaves the adaptive pause uncapped.
+func newLimiter(options *LimiterOptions, maxPause time.Duration) *limiter {
+	if options == nil {
+		return nil
+	}
+
+	l := &limiter{
+		rate:     options.RequestsPerSecond,
+		adaptive: !options.DisableAdaptive,
+		maxPause: maxPause,
+		last:     time.Now(),
+	}
+	if l.rate > 0 {
+		l.burst = float64(options.Burst)
+		if l.burst <= 0 {
+			l.burst = math.Max(1, math.Ceil(l.rate))
+		}
+		l.tokens = l.burst
+	}
+	if options.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, options.MaxInFlight)
+	}
+	return l
+}
+
+// acquire blocks until an attempt may start. The returned function frees the in-flight slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		delay := l.reserve(time.Now())
+		if delay <= 0 {
+			return nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return ErrLimiterDeadline
+		}
+		if err := sleepWithContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// reserve takes a token and returns zero, or returns how long to wait before trying again.
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Before(l.pausedUntil) {
+		return l.pausedUntil.Sub(now)
+	}
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// observe pauses every request until the server-reported quota resets, for at most maxPause.
+func (l *limiter) observe(status int, headers http.Header) {
+	if l == nil || !l.adaptive {
+		return
+	}
+
+	now := time.Now()
+	meta := newResponseMeta(status, "", headers, now)
+
+	var until time.Time
+	switch {
+	case status == http.StatusTooManyRequests && meta.HasRetryAfter:
+		until = now.Add(meta.RetryAfter)
+	case meta.RateLimit != nil && headers.Get("X-RateLimit-Remaining") == "0" && !meta.RateLimit.Reset.IsZero():
+		until = meta.RateLimit.Reset
+	default:
+		return
+	}
+	if l.maxPause > 0 && until.Sub(now) > l.maxPause {
+		until = now.Add(l.maxPause)
+	}
+
+	l.mu.Lock()
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.mu.Unlock()
+}
@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiterCapsInFlightRequests(t *testing.T) {
+	var current, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		current.Add(-1)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client, err := New(Options{Auth: "rw", BaseURL: server.URL, Limiter: &LimiterOptions{MaxInFlight: 2}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Get(context.Background(), "/projects/1", nil, nil); err != nil {
+				t.Errorf("unexpected get error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() > 2 {
+		t.Fatalf("expected at most 2 in-flight requests, got %d", peak.Load())
+	}
+}
+
+func TestLimiterTokenBucket(t *testing.T) {
+	l := newLimiter(&LimiterOptions{RequestsPerSecond: 10, Burst: 2}, 0)
+	now := l.last
+
+	if l.reserve(now) != 0 || l.reserve(now) != 0 {
+		t.Fatal("expected the burst to be available immediately")
+	}
+	if wait := l.reserve(now); wait <= 0 || wait > 100*time.Millisecond {
+		t.Fatalf("expected a wait of up to 100ms, got %v", wait)
+	}
+	if l.reserve(now.Add(100*time.Millisecond)) != 0 {
+		t.Fatal("expected a token after refilling")
+	}
+}
+
+func TestLimiterAdaptsToServerQuota(t *testing.T) {
+	l := newLimiter(&LimiterOptions{}, time.Minute)
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", "30")
+	l.observe(http.StatusOK, headers)
+
+	if wait := l.reserve(time.Now()); wait < 29*time.Second {
+		t.Fatalf("expected the limiter to pause until reset, got %v", wait)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, ErrLimiterDeadline) {
+		t.Fatalf("expected ErrLimiterDeadline, got %v", err)
+	}
+}
+
+func TestLimiterPauseIsCappedByMaxRetryAfter(t *testing.T) {
+	l := newLimiter(&LimiterOptions{}, time.Minute)
+	headers := http.Header{}
+	headers.Set("Retry-After", "999999999999")
+	l.observe(http.StatusTooManyRequests, headers)
+	if wait := l.reserve(time.Now()); wait <= 0 || wait > time.Minute {
+		t.Fatalf("expected a pause of at most a minute, got %v", wait)
+	}
+
+	l = newLimiter(&LimiterOptions{}, time.Minute)
+	headers = http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", "4102444800")
+	l.observe(http.StatusOK, headers)
+	if wait := l.reserve(time.Now()); wait <= 0 || wait > time.Minute {
+		t.Fatalf("expected a pause of at most a minute, got %v", wait)
+	}
+}
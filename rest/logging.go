@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// defaultRedactFields are JSON body fields that are always redacted in logs.
+var defaultRedactFields = []string{"key", "secret", "token", "password", "authorization"}
+
+func (c *Client) logEnabled(ctx context.Context, level slog.Level) bool {
+	return c.options.Logger != nil && c.options.Logger.Enabled(ctx, level)
+}
+
+func (c *Client) logAttempt(ctx context.Context, options FetchOptions, route string, attempt int, response *response, err error, elapsed time.Duration) {
+	if !c.logEnabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", options.method),
+		slog.String("route", routePath(route)),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", elapsed),
+	}
+	if response != nil {
+		attrs = append(attrs, slog.Int("status", response.status))
+		if id := response.header.Get(requestIDHeader); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", c.redactString(err.Error())))
+	}
+	if c.options.LogBodies {
+		if options.hasData {
+			if encoded, ok := options.data.([]byte); ok {
+				attrs = append(attrs, slog.String("request_body", c.redactBody(encoded)))
+			} else if encoded, err := json.Marshal(options.data); err == nil {
+				attrs = append(attrs, slog.String("request_body", c.redactBody(encoded)))
+			}
+		}
+		if response != nil {
+			attrs = append(attrs, slog.String("response_body", c.redactBody(response.body)))
+		}
+	}
+
+	c.options.Logger.LogAttrs(ctx, slog.LevelDebug, "rewrite request", attrs...)
+}
+
+func (c *Client) logRetry(ctx context.Context, retry HandleErrorOptions) {
+	if !c.logEnabled(ctx, slog.LevelWarn) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", retry.Method),
+		slog.String("route", routePath(retry.Route)),
+		slog.Int("attempt", retry.Attempt),
+		slog.String("reason", string(retry.Reason)),
+		slog.Duration("delay", retry.Delay),
+	}
+	if retry.Response != nil {
+		attrs = append(attrs, slog.Int("status", retry.Response.Status))
+	}
+	if retry.Err != nil {
+		attrs = append(attrs, slog.String("error", c.redactString(retry.Err.Error())))
+	}
+
+	c.options.Logger.LogAttrs(ctx, slog.LevelWarn, "rewrite retry", attrs...)
+}
+
+func (c *Client) logFailure(ctx context.Context, options FetchOptions, route string, attempt int, err error) {
+	if !c.logEnabled(ctx, slog.LevelError) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", options.method),
+		slog.String("route", routePath(route)),
+		slog.Int("attempts", attempt+1),
+		slog.String("error", c.redactString(err.Error())),
+	}
+	if httpErr, ok := err.(*HTTPError); ok {
+		attrs = append(attrs, slog.Int("status", httpErr.Status))
+		if httpErr.Code != "" {
+			attrs = append(attrs, slog.String("code", httpErr.Code))
+		}
+		if httpErr.RequestID != "" {
+			attrs = append(attrs, slog.String("request_id", httpErr.RequestID))
+		}
+	}
+
+	c.options.Logger.LogAttrs(ctx, slog.LevelError, "rewrite request failed", attrs...)
+}
+
+// redactBody replaces configured JSON fields and the API secret in body.
+func (c *Client) redactBody(body []byte) string {
+	return string(c.redactJSON(body, nil))
+}
+
+// redactJSON replaces the default, configured and extra JSON fields and the API secret in body.
+func (c *Client) redactJSON(body []byte, extra []string) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return []byte(c.redactString(string(body)))
+	}
+
+	fields := make(map[string]struct{}, len(defaultRedactFields)+len(c.options.RedactFields)+len(extra))
+	for _, field := range defaultRedactFields {
+		fields[field] = struct{}{}
+	}
+	for _, field := range c.options.RedactFields {
+		fields[strings.ToLower(field)] = struct{}{}
+	}
+	for _, field := range extra {
+		fields[strings.ToLower(field)] = struct{}{}
+	}
+
+	encoded, err := json.Marshal(redactValue(parsed, fields))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return []byte(c.redactString(string(encoded)))
+}
+
+func (c *Client) redactString(value string) string {
+	if c.options.Auth == "" {
+		return value
+	}
+	return strings.ReplaceAll(value, c.options.Auth, redacted)
+}
+
+func redactValue(value any, fields map[string]struct{}) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if _, ok := fields[strings.ToLower(key)]; ok {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(child, fields)
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = redactValue(child, fields)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func routePath(route string) string {
+	path, _, _ := strings.Cut(route, "?")
+	return path
+}
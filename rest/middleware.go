@@ -0,0 +1,51 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+)
+
+// Handler sends a single HTTP attempt.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to inspect or modify requests and responses.
+//
+// Middleware runs once per attempt, so retries pass through the chain again.
+// The request context carries a RequestInfo describing the logical call.
+type Middleware func(next Handler) Handler
+
+// RequestInfo describes the logical call an HTTP attempt belongs to.
+type RequestInfo struct {
+	// Operation names the SDK method, e.g. Webhooks.Update. It may be empty for raw calls.
+	Operation string
+	// Method is the HTTP method.
+	Method string
+	// Route is the API route without base URL or query, e.g. /projects/1/webhooks.
+	Route string
+	// RouteTemplate is Route with IDs replaced, e.g. /projects/{id}/webhooks.
+	RouteTemplate string
+	// Attempt is zero for the first try and increases on every retry.
+	Attempt int
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo stored on a request context.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+func withRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// chain applies middleware so that the first entry is the outermost.
+func chain(handler Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			handler = middleware[i](handler)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// CallInfo describes a logical API call, which may span several HTTP attempts.
+type CallInfo struct {
+	// Operation names the SDK method, e.g. Webhooks.Update. It may be empty for raw calls.
+	Operation string
+	// Method is the HTTP method.
+	Method string
+	// Route is the API route without query, e.g. /projects/1/webhooks/2.
+	Route string
+	// RouteTemplate is Route with IDs replaced, e.g. /projects/{id}/webhooks/{webhookId}.
+	RouteTemplate string
+}
+
+// CallResult describes how a logical API call ended.
+type CallResult struct {
+	// Attempts is the number of HTTP attempts made, including retries.
+	Attempts int
+	// Status is the last HTTP status received, or zero when no response arrived.
+	Status int
+	// Err is the error returned to the caller, if any.
+	Err error
+}
+
+// CallObserver is notified when a logical call starts. The returned context is
+// used for every attempt, and the returned function runs once the call ends.
+type CallObserver func(ctx context.Context, info CallInfo) (context.Context, func(result CallResult))
+
+// callState tracks a logical call across retries.
+type callState struct {
+	info     CallInfo
+	attempts int
+	status   int
+}
+
+func (c *Client) call(ctx context.Context, route string, out any, options FetchOptions) error {
+	state := &callState{
+		info: CallInfo{
+			Operation:     options.Operation,
+			Method:        options.method,
+			Route:         routePath(route),
+			RouteTemplate: api.RouteTemplate(route),
+		},
+	}
+	options.state = state
+
+	if err := c.CheckScopes(options.Operation, options.Scopes...); err != nil {
+		return err
+	}
+
+	if len(c.options.Observers) == 0 {
+		return c.fetch(ctx, route, out, options, 0)
+	}
+
+	done := make([]func(CallResult), 0, len(c.options.Observers))
+	for _, observer := range c.options.Observers {
+		if observer == nil {
+			continue
+		}
+		var end func(CallResult)
+		ctx, end = observer(ctx, state.info)
+		if end != nil {
+			done = append(done, end)
+		}
+	}
+
+	err := c.fetch(ctx, route, out, options, 0)
+
+	result := CallResult{Attempts: state.attempts, Status: state.status, Err: err}
+	for i := len(done) - 1; i >= 0; i-- {
+		done[i](result)
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetryAfter = time.Minute
+
+	// Header values above this are Unix timestamps rather than delays in seconds.
+	unixResetThreshold = 1_000_000_000
+)
+
+// RateLimit holds the X-RateLimit-* headers of a response.
+type RateLimit struct {
+	// Limit is the request quota for the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is when the current window resets. Zero when unknown.
+	Reset time.Time
+}
+
+// maxRetryAfter resolves RetryOptions.MaxRetryAfter. A non-positive result means no cap.
+func maxRetryAfter(retry *RetryOptions) time.Duration {
+	if retry != nil && retry.MaxRetryAfter != 0 {
+		return retry.MaxRetryAfter
+	}
+	return defaultMaxRetryAfter
+}
+
+func newResponseMeta(status int, url string, headers http.Header, now time.Time) *ResponseMeta {
+	meta := &ResponseMeta{
+		Status:  status,
+		URL:     url,
+		Headers: headers,
+	}
+	meta.RetryAfter, meta.HasRetryAfter = parseRetryAfter(headers.Get("Retry-After"), now)
+	meta.RateLimit = parseRateLimit(headers, now)
+	return meta
+}
+
+// maxDelaySeconds is the longest delay-seconds value that fits in a time.Duration.
+const maxDelaySeconds = int64(math.MaxInt64 / time.Second)
+
+// parseRetryAfter accepts both the delay-seconds and HTTP-date forms.
+//
+// Delay-seconds must be a non-negative integer (RFC 9110, section 10.2.3).
+// Values too large for a time.Duration saturate, so they always exceed MaxRetryAfter.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if isDigits(value) {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || seconds > maxDelaySeconds {
+			return time.Duration(math.MaxInt64), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		wait := at.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseRateLimit(headers http.Header, now time.Time) *RateLimit {
+	limit, hasLimit := parseHeaderInt(headers, "X-RateLimit-Limit")
+	remaining, hasRemaining := parseHeaderInt(headers, "X-RateLimit-Remaining")
+	reset, hasReset := parseHeaderInt(headers, "X-RateLimit-Reset")
+	if !hasLimit && !hasRemaining && !hasReset {
+		return nil
+	}
+
+	out := &RateLimit{Limit: limit, Remaining: remaining}
+	if hasReset {
+		if reset > unixResetThreshold {
+			out.Reset = time.Unix(int64(reset), 0)
+		} else {
+			out.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return out
+}
+
+func parseHeaderInt(headers http.Header, key string) (int, bool) {
+	value := strings.TrimSpace(headers.Get(key))
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// serverDelay returns the wait requested by the server, if any.
+func (m *ResponseMeta) serverDelay(now time.Time) (time.Duration, bool) {
+	if m.HasRetryAfter {
+		return m.RetryAfter, true
+	}
+	if m.Status == http.StatusTooManyRequests && m.RateLimit != nil && m.RateLimit.Remaining == 0 && !m.RateLimit.Reset.IsZero() {
+		wait := m.RateLimit.Reset.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+)
+
+// RetryReason tells retry callbacks what triggered a retry decision.
+type RetryReason string
+
+const (
+	// RetryReasonStatus means the API answered with an error status code.
+	RetryReasonStatus RetryReason = "status"
+	// RetryReasonTransport means the request failed before a response was received.
+	RetryReasonTransport RetryReason = "transport"
+)
+
+var idempotentMethods = map[string]struct{}{
+	"GET":     {},
+	"HEAD":    {},
+	"OPTIONS": {},
+	"PUT":     {},
+	"DELETE":  {},
+}
+
+// DefaultShouldRetry is the classifier used when RetryOptions.ShouldRetry is nil.
+//
+// It retries 408, 425, 429, 500, 502, 503 and 504 responses for every method,
+// and transport failures (connection resets, DNS errors, TLS handshake errors,
+// per-attempt timeouts) for idempotent methods or requests carrying an idempotency key.
+func DefaultShouldRetry(options HandleErrorOptions) bool {
+	switch options.Reason {
+	case RetryReasonStatus:
+		return options.Response != nil && isRetryableStatus(options.Response.Status)
+	case RetryReasonTransport:
+		idempotent := isIdempotent(options.Method) || options.Options.IdempotencyKey != ""
+		return idempotent && isTransportError(options.Err)
+	}
+	return false
+}
+
+// NewIdempotencyKey returns a random UUIDv4 suitable for FetchOptions.IdempotencyKey.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func isIdempotent(method string) bool {
+	_, ok := idempotentMethods[method]
+	return ok
+}
+
+// isTransportError reports whether err is a network-level failure worth retrying.
+func isTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var certErr *tls.CertificateVerificationError
+	if errors.As(err, &certErr) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var recordErr tls.RecordHeaderError
+	if errors.As(err, &recordErr) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && urlErr.Err != nil && errors.Is(urlErr.Err, net.ErrClosed)
+}
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// ErrMissingScope matches ScopeError through errors.Is.
+var ErrMissingScope = errors.New("API key is missing a required scope")
+
+// ScopeError is returned when Options.Scopes does not grant what a request needs.
+type ScopeError struct {
+	// Operation names the SDK method, e.g. Templates.Create. It may be empty for raw calls.
+	Operation string
+	// Missing lists the scopes the API key lacks.
+	Missing api.Scopes
+}
+
+// Error implements the error interface.
+func (e *ScopeError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, scope := range e.Missing {
+		missing[i] = string(scope)
+	}
+	if e.Operation == "" {
+		return fmt.Sprintf("API key is missing the %s scope", strings.Join(missing, ", "))
+	}
+	return fmt.Sprintf("API key is missing the %s scope for %s", strings.Join(missing, ", "), e.Operation)
+}
+
+// Is lets errors.Is match ErrMissingScope.
+func (e *ScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+// CheckScopes returns a ScopeError when Options.Scopes is set and does not grant required.
+func (c *Client) CheckScopes(operation string, required ...api.APIKeyScope) error {
+	if c.options.Scopes == nil {
+		return nil
+	}
+	if missing := c.options.Scopes.Missing(required...); len(missing) > 0 {
+		return &ScopeError{Operation: operation, Missing: missing}
+	}
+	return nil
+}
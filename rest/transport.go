@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// response is a fully read HTTP response.
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+	url    string
+}
+
+func (r *response) isError() bool {
+	return r.status > 399
+}
+
+// newHTTPClient resolves the *http.Client used by a REST client.
+//
+// HTTPClient wins over Transport, and the proxy, TLS and pooling knobs only
+// apply to the default transport.
+func newHTTPClient(options Options) *http.Client {
+	if options.HTTPClient != nil {
+		return options.HTTPClient
+	}
+	if options.Transport != nil {
+		return &http.Client{Transport: options.Transport}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.Proxy != nil {
+		transport.Proxy = options.Proxy
+	}
+	if options.TLSConfig != nil {
+		transport.TLSClientConfig = options.TLSConfig.Clone()
+	}
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConns = options.MaxIdleConns
+	}
+	if options.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConnsPerHost
+	}
+	if options.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = options.MaxConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	return &http.Client{Transport: transport}
+}
+
+// encodeBody encodes request data. Byte slices and strings are sent as-is;
+// anything else is encoded as JSON.
+func encodeBody(data any) (io.Reader, bool, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, false, nil
+	case []byte:
+		return bytes.NewReader(v), false, nil
+	case string:
+		return bytes.NewReader([]byte(v)), false, nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false, err
+		}
+		return bytes.NewReader(encoded), true, nil
+	}
+}
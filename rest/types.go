@@ -1,6 +1,14 @@
 package rest
 
-import "time"
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
 
 // Options configures the low-level REST client.
 type Options struct {
@@ -8,12 +16,54 @@ type Options struct {
 	BaseURL string
 	// Auth is the Rewrite API secret used in the Bearer token.
 	Auth string
+	// Scopes lists the permissions granted to Auth. When set, requests needing a
+	// scope outside it fail with a ScopeError before anything is sent.
+	Scopes api.Scopes
 	// Timeout is the default per-request timeout.
 	Timeout time.Duration
 	// Headers are merged into every outgoing request.
 	Headers map[string]string
 	// Retry configures retry behavior for retryable HTTP statuses.
 	Retry *RetryOptions
+	// Limiter enables client-side rate and concurrency limiting shared by every
+	// request made through the client. Nil disables it.
+	Limiter *LimiterOptions
+	// CircuitBreaker fails requests fast with ErrCircuitOpen during sustained outages. Nil disables it.
+	CircuitBreaker *CircuitBreakerOptions
+	// Middleware wraps every HTTP attempt. The first entry is the outermost.
+	Middleware []Middleware
+	// Cassette records real interactions to a file or replays them offline.
+	// It sits inside Middleware, right before the network. Nil disables it.
+	Cassette *CassetteOptions
+	// Observers are notified when each logical call starts and ends.
+	Observers []CallObserver
+	// Logger receives request, retry and failure logs. Logging is disabled when nil.
+	// The API secret is always redacted.
+	Logger *slog.Logger
+	// LogBodies adds redacted request and response bodies to debug logs.
+	LogBodies bool
+	// RedactFields lists extra JSON body fields to redact, in addition to
+	// key, secret, token, password and authorization.
+	RedactFields []string
+
+	// HTTPClient is used for every request when set. Transport, Proxy, TLSConfig and
+	// the connection pool options are ignored, since the client is used as-is.
+	HTTPClient *http.Client
+	// Transport wraps requests in a default http.Client when HTTPClient is nil.
+	Transport http.RoundTripper
+	// Proxy selects the proxy for the default transport, e.g. http.ProxyURL(u).
+	// The default transport honours HTTP_PROXY/HTTPS_PROXY when nil.
+	Proxy func(*http.Request) (*url.URL, error)
+	// TLSConfig configures the default transport, e.g. custom root CAs or client certificates.
+	TLSConfig *tls.Config
+	// MaxIdleConns caps idle connections across all hosts for the default transport.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps idle connections per host for the default transport.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps total connections per host for the default transport.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes idle connections after this duration for the default transport.
+	IdleConnTimeout time.Duration
 }
 
 // RetryOptions controls retry behavior for failed requests.
@@ -24,6 +74,12 @@ type RetryOptions struct {
 	Delay func(attempt int) time.Duration
 	// OnRetry runs before each retry attempt.
 	OnRetry func(options HandleErrorOptions)
+	// ShouldRetry decides whether a failed attempt is retried. When nil, DefaultShouldRetry is used.
+	ShouldRetry func(options HandleErrorOptions) bool
+	// MaxRetryAfter caps the wait requested through Retry-After or X-RateLimit-Reset.
+	// Longer waits fail immediately instead of retrying. When zero, one minute is used.
+	// A negative value disables the cap.
+	MaxRetryAfter time.Duration
 }
 
 // FetchOptions customizes an individual REST request.
@@ -34,23 +90,44 @@ type FetchOptions struct {
 	Timeout time.Duration
 	// Query appends query params. Supported values: string, map[string]string, url.Values, [][2]string.
 	Query any
+	// IdempotencyKey is sent as the Idempotency-Key header and reused on every retry.
+	// POST requests get a random key when empty.
+	IdempotencyKey string
+	// Operation names the SDK method for observers and middleware, e.g. Webhooks.Update.
+	Operation string
+	// Scopes lists the API key scopes the request needs. See Options.Scopes.
+	Scopes api.Scopes
 
 	method  string
 	data    any
 	hasData bool
+	state   *callState
 }
 
-// HandleErrorOptions are passed to RetryOptions.OnRetry.
+// HandleErrorOptions are passed to RetryOptions.OnRetry and RetryOptions.ShouldRetry.
 type HandleErrorOptions struct {
-	Method   string
-	Route    string
-	Attempt  int
+	Method  string
+	Route   string
+	Attempt int
+	// Reason tells whether a status code or a transport error triggered the retry.
+	Reason RetryReason
+	// Response is nil when Reason is RetryReasonTransport.
 	Response *ResponseMeta
-	Options  FetchOptions
+	// Err is the transport error when Reason is RetryReasonTransport.
+	Err     error
+	Options FetchOptions
+	// Delay is the wait before the next attempt.
+	Delay time.Duration
 }
 
 // ResponseMeta provides response metadata to retry callbacks.
 type ResponseMeta struct {
-	Status int
-	URL    string
+	Status  int
+	URL     string
+	Headers http.Header
+	// RetryAfter is the parsed Retry-After header. HasRetryAfter reports whether it was sent.
+	RetryAfter    time.Duration
+	HasRetryAfter bool
+	// RateLimit holds the X-RateLimit-* headers, or nil when absent.
+	RateLimit *RateLimit
 }
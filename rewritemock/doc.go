@@ -0,0 +1,19 @@
+// Package rewritemock provides mock implementations of the resource service
+// interfaces for unit tests that should not touch the network.
+//
+// Stub only the methods a test needs and inspect the recorded calls:
+//
+//	templates := &rewritemock.Templates{
+//		GetDataFunc: func(ctx context.Context, identifier, project string) (api.APITemplate, error) {
+//			return api.APITemplate{Name: identifier}, nil
+//		},
+//	}
+//	services := rewrite.Services{Templates: templates}
+//
+//	// ... exercise code that depends on rewrite.Services ...
+//
+//	calls := templates.CallsTo("Templates.GetData")
+//
+// For tests that should run real resource code against a stateful API, see the
+// rewritetest package instead.
+package rewritemock
@@ -0,0 +1,68 @@
+package rewritemock
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// ErrNotStubbed is returned by mock methods whose func field is nil.
+var ErrNotStubbed = errors.New("Method is not stubbed")
+
+// Call is a recorded mock method call.
+type Call struct {
+	// Method names the service method, e.g. Templates.Create.
+	Method string
+	// Args holds the arguments after the context.
+	Args []any
+}
+
+// Recorder records calls made to a mock. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns every recorded call in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// CallsTo returns the recorded calls to method, e.g. Templates.Create.
+func (r *Recorder) CallsTo(method string) []Call {
+	var out []Call
+	for _, call := range r.Calls() {
+		if call.Method == method {
+			out = append(out, call)
+		}
+	}
+	return out
+}
+
+// Reset forgets every recorded call.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotStubbed)
+}
+
+func notStubbedSeq[T any](method string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, notStubbed(method))
+	}
+}
@@ -0,0 +1,463 @@
+package rewritemock
+
+import (
+	"context"
+	"iter"
+
+	"github.com/rewritetoday/golang/api"
+	"github.com/rewritetoday/golang/resources"
+)
+
+// Templates is a mock resources.TemplatesService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type Templates struct {
+	Recorder
+
+	CreateFunc  func(ctx context.Context, options resources.CreateTemplateOptions) (api.RESTPostCreateTemplateData, error)
+	UpdateFunc  func(ctx context.Context, id string, options resources.UpdateTemplateOptions) (api.RESTPatchUpdateTemplateData, error)
+	DeleteFunc  func(ctx context.Context, id, project string) error
+	ListFunc    func(ctx context.Context, project string, query *api.RESTGetListTemplatesQueryParams) (api.RESTGetListTemplatesData, error)
+	AllFunc     func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APITemplate, error]
+	GetFunc     func(ctx context.Context, identifier, project string) (api.RESTGetTemplateData, error)
+	GetDataFunc func(ctx context.Context, identifier, project string) (api.APITemplate, error)
+}
+
+var _ resources.TemplatesService = (*Templates)(nil)
+
+// Create implements resources.TemplatesService.
+func (m *Templates) Create(ctx context.Context, options resources.CreateTemplateOptions) (api.RESTPostCreateTemplateData, error) {
+	m.record("Templates.Create", options)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, options)
+	}
+	return api.RESTPostCreateTemplateData{}, notStubbed("Templates.Create")
+}
+
+// Update implements resources.TemplatesService.
+func (m *Templates) Update(ctx context.Context, id string, options resources.UpdateTemplateOptions) (api.RESTPatchUpdateTemplateData, error) {
+	m.record("Templates.Update", id, options)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, options)
+	}
+	return api.RESTPatchUpdateTemplateData{}, notStubbed("Templates.Update")
+}
+
+// Delete implements resources.TemplatesService.
+func (m *Templates) Delete(ctx context.Context, id, project string) error {
+	m.record("Templates.Delete", id, project)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id, project)
+	}
+	return notStubbed("Templates.Delete")
+}
+
+// List implements resources.TemplatesService.
+func (m *Templates) List(ctx context.Context, project string, query *api.RESTGetListTemplatesQueryParams) (api.RESTGetListTemplatesData, error) {
+	m.record("Templates.List", project, query)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, project, query)
+	}
+	return api.RESTGetListTemplatesData{}, notStubbed("Templates.List")
+}
+
+// All implements resources.TemplatesService.
+func (m *Templates) All(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APITemplate, error] {
+	m.record("Templates.All", project, options)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APITemplate]("Templates.All")
+}
+
+// Get implements resources.TemplatesService.
+func (m *Templates) Get(ctx context.Context, identifier, project string) (api.RESTGetTemplateData, error) {
+	m.record("Templates.Get", identifier, project)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, identifier, project)
+	}
+	return api.RESTGetTemplateData{}, notStubbed("Templates.Get")
+}
+
+// GetData implements resources.TemplatesService.
+func (m *Templates) GetData(ctx context.Context, identifier, project string) (api.APITemplate, error) {
+	m.record("Templates.GetData", identifier, project)
+	if m.GetDataFunc != nil {
+		return m.GetDataFunc(ctx, identifier, project)
+	}
+	return api.APITemplate{}, notStubbed("Templates.GetData")
+}
+
+// Webhooks is a mock resources.WebhooksService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type Webhooks struct {
+	Recorder
+
+	CreateFunc  func(ctx context.Context, options resources.CreateWebhookOptions) (api.RESTPostCreateWebhookData, error)
+	UpdateFunc  func(ctx context.Context, id string, options resources.UpdateWebhookOptions) (api.RESTPatchUpdateWebhookData, error)
+	DeleteFunc  func(ctx context.Context, id, project string) error
+	ListFunc    func(ctx context.Context, project string, query *api.RESTGetListWebhooksQueryParams) (api.RESTGetListWebhooksData, error)
+	AllFunc     func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIWebhook, error]
+	GetFunc     func(ctx context.Context, id, project string) (api.RESTGetWebhookData, error)
+	GetDataFunc func(ctx context.Context, id, project string) (api.APIWebhook, error)
+}
+
+var _ resources.WebhooksService = (*Webhooks)(nil)
+
+// Create implements resources.WebhooksService.
+func (m *Webhooks) Create(ctx context.Context, options resources.CreateWebhookOptions) (api.RESTPostCreateWebhookData, error) {
+	m.record("Webhooks.Create", options)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, options)
+	}
+	return api.RESTPostCreateWebhookData{}, notStubbed("Webhooks.Create")
+}
+
+// Update implements resources.WebhooksService.
+func (m *Webhooks) Update(ctx context.Context, id string, options resources.UpdateWebhookOptions) (api.RESTPatchUpdateWebhookData, error) {
+	m.record("Webhooks.Update", id, options)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, options)
+	}
+	return api.RESTPatchUpdateWebhookData{}, notStubbed("Webhooks.Update")
+}
+
+// Delete implements resources.WebhooksService.
+func (m *Webhooks) Delete(ctx context.Context, id, project string) error {
+	m.record("Webhooks.Delete", id, project)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id, project)
+	}
+	return notStubbed("Webhooks.Delete")
+}
+
+// List implements resources.WebhooksService.
+func (m *Webhooks) List(ctx context.Context, project string, query *api.RESTGetListWebhooksQueryParams) (api.RESTGetListWebhooksData, error) {
+	m.record("Webhooks.List", project, query)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, project, query)
+	}
+	return api.RESTGetListWebhooksData{}, notStubbed("Webhooks.List")
+}
+
+// All implements resources.WebhooksService.
+func (m *Webhooks) All(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIWebhook, error] {
+	m.record("Webhooks.All", project, options)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APIWebhook]("Webhooks.All")
+}
+
+// Get implements resources.WebhooksService.
+func (m *Webhooks) Get(ctx context.Context, id, project string) (api.RESTGetWebhookData, error) {
+	m.record("Webhooks.Get", id, project)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id, project)
+	}
+	return api.RESTGetWebhookData{}, notStubbed("Webhooks.Get")
+}
+
+// GetData implements resources.WebhooksService.
+func (m *Webhooks) GetData(ctx context.Context, id, project string) (api.APIWebhook, error) {
+	m.record("Webhooks.GetData", id, project)
+	if m.GetDataFunc != nil {
+		return m.GetDataFunc(ctx, id, project)
+	}
+	return api.APIWebhook{}, notStubbed("Webhooks.GetData")
+}
+
+// APIKeys is a mock resources.APIKeysService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type APIKeys struct {
+	Recorder
+
+	CreateFunc  func(ctx context.Context, options resources.CreateAPIKeyOptions) (api.RESTPostCreateAPIKeyData, error)
+	UpdateFunc  func(ctx context.Context, id string, options resources.UpdateAPIKeyOptions) (api.RESTPatchUpdateAPIKeyData, error)
+	DeleteFunc  func(ctx context.Context, id, project string) error
+	ListFunc    func(ctx context.Context, project string, query *api.RESTGetListAPIKeysQueryParams) (api.RESTGetListAPIKeysData, error)
+	AllFunc     func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIAPIKey, error]
+	GetFunc     func(ctx context.Context, id, project string) (api.RESTGetAPIKeyData, error)
+	GetDataFunc func(ctx context.Context, id, project string) (api.APIAPIKey, error)
+	RotateFunc  func(ctx context.Context, id string, options resources.RotateAPIKeyOptions) (api.APICreatedAPIKey, error)
+}
+
+var _ resources.APIKeysService = (*APIKeys)(nil)
+
+// Create implements resources.APIKeysService.
+func (m *APIKeys) Create(ctx context.Context, options resources.CreateAPIKeyOptions) (api.RESTPostCreateAPIKeyData, error) {
+	m.record("APIKeys.Create", options)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, options)
+	}
+	return api.RESTPostCreateAPIKeyData{}, notStubbed("APIKeys.Create")
+}
+
+// Update implements resources.APIKeysService.
+func (m *APIKeys) Update(ctx context.Context, id string, options resources.UpdateAPIKeyOptions) (api.RESTPatchUpdateAPIKeyData, error) {
+	m.record("APIKeys.Update", id, options)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, id, options)
+	}
+	return api.RESTPatchUpdateAPIKeyData{}, notStubbed("APIKeys.Update")
+}
+
+// Delete implements resources.APIKeysService.
+func (m *APIKeys) Delete(ctx context.Context, id, project string) error {
+	m.record("APIKeys.Delete", id, project)
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, id, project)
+	}
+	return notStubbed("APIKeys.Delete")
+}
+
+// List implements resources.APIKeysService.
+func (m *APIKeys) List(ctx context.Context, project string, query *api.RESTGetListAPIKeysQueryParams) (api.RESTGetListAPIKeysData, error) {
+	m.record("APIKeys.List", project, query)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, project, query)
+	}
+	return api.RESTGetListAPIKeysData{}, notStubbed("APIKeys.List")
+}
+
+// All implements resources.APIKeysService.
+func (m *APIKeys) All(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIAPIKey, error] {
+	m.record("APIKeys.All", project, options)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APIAPIKey]("APIKeys.All")
+}
+
+// Get implements resources.APIKeysService.
+func (m *APIKeys) Get(ctx context.Context, id, project string) (api.RESTGetAPIKeyData, error) {
+	m.record("APIKeys.Get", id, project)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id, project)
+	}
+	return api.RESTGetAPIKeyData{}, notStubbed("APIKeys.Get")
+}
+
+// GetData implements resources.APIKeysService.
+func (m *APIKeys) GetData(ctx context.Context, id, project string) (api.APIAPIKey, error) {
+	m.record("APIKeys.GetData", id, project)
+	if m.GetDataFunc != nil {
+		return m.GetDataFunc(ctx, id, project)
+	}
+	return api.APIAPIKey{}, notStubbed("APIKeys.GetData")
+}
+
+// Rotate implements resources.APIKeysService.
+func (m *APIKeys) Rotate(ctx context.Context, id string, options resources.RotateAPIKeyOptions) (api.APICreatedAPIKey, error) {
+	m.record("APIKeys.Rotate", id, options)
+	if m.RotateFunc != nil {
+		return m.RotateFunc(ctx, id, options)
+	}
+	return api.APICreatedAPIKey{}, notStubbed("APIKeys.Rotate")
+}
+
+// Messages is a mock resources.MessagesService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type Messages struct {
+	Recorder
+
+	SendFunc    func(ctx context.Context, options resources.SendMessageOptions) (api.RESTPostSendMessageData, error)
+	GetFunc     func(ctx context.Context, id, project string) (api.RESTGetMessageData, error)
+	GetDataFunc func(ctx context.Context, id, project string) (api.APIMessage, error)
+	ListFunc    func(ctx context.Context, project string, query *api.RESTGetListMessagesQueryParams) (api.RESTGetListMessagesData, error)
+	AllFunc     func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIMessage, error]
+	CancelFunc  func(ctx context.Context, id string, options resources.CancelMessageOptions) (api.RESTPostCancelMessageData, error)
+}
+
+var _ resources.MessagesService = (*Messages)(nil)
+
+// Send implements resources.MessagesService.
+func (m *Messages) Send(ctx context.Context, options resources.SendMessageOptions) (api.RESTPostSendMessageData, error) {
+	m.record("Messages.Send", options)
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, options)
+	}
+	return api.RESTPostSendMessageData{}, notStubbed("Messages.Send")
+}
+
+// Get implements resources.MessagesService.
+func (m *Messages) Get(ctx context.Context, id, project string) (api.RESTGetMessageData, error) {
+	m.record("Messages.Get", id, project)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id, project)
+	}
+	return api.RESTGetMessageData{}, notStubbed("Messages.Get")
+}
+
+// GetData implements resources.MessagesService.
+func (m *Messages) GetData(ctx context.Context, id, project string) (api.APIMessage, error) {
+	m.record("Messages.GetData", id, project)
+	if m.GetDataFunc != nil {
+		return m.GetDataFunc(ctx, id, project)
+	}
+	return api.APIMessage{}, notStubbed("Messages.GetData")
+}
+
+// List implements resources.MessagesService.
+func (m *Messages) List(ctx context.Context, project string, query *api.RESTGetListMessagesQueryParams) (api.RESTGetListMessagesData, error) {
+	m.record("Messages.List", project, query)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, project, query)
+	}
+	return api.RESTGetListMessagesData{}, notStubbed("Messages.List")
+}
+
+// All implements resources.MessagesService.
+func (m *Messages) All(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIMessage, error] {
+	m.record("Messages.All", project, options)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APIMessage]("Messages.All")
+}
+
+// Cancel implements resources.MessagesService.
+func (m *Messages) Cancel(ctx context.Context, id string, options resources.CancelMessageOptions) (api.RESTPostCancelMessageData, error) {
+	m.record("Messages.Cancel", id, options)
+	if m.CancelFunc != nil {
+		return m.CancelFunc(ctx, id, options)
+	}
+	return api.RESTPostCancelMessageData{}, notStubbed("Messages.Cancel")
+}
+
+// Projects is a mock resources.ProjectsService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type Projects struct {
+	Recorder
+
+	GetFunc     func(ctx context.Context, id string) (api.RESTGetProjectData, error)
+	GetDataFunc func(ctx context.Context, id string) (api.APIProject, error)
+	UpdateFunc  func(ctx context.Context, options resources.UpdateProjectOptions) (api.RESTPatchUpdateProjectData, error)
+	ListFunc    func(ctx context.Context, query *api.RESTGetListProjectsQueryParams) (api.RESTGetListProjectsData, error)
+	AllFunc     func(ctx context.Context, options *resources.PaginateOptions) iter.Seq2[api.APIProject, error]
+}
+
+var _ resources.ProjectsService = (*Projects)(nil)
+
+// Get implements resources.ProjectsService.
+func (m *Projects) Get(ctx context.Context, id string) (api.RESTGetProjectData, error) {
+	m.record("Projects.Get", id)
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id)
+	}
+	return api.RESTGetProjectData{}, notStubbed("Projects.Get")
+}
+
+// GetData implements resources.ProjectsService.
+func (m *Projects) GetData(ctx context.Context, id string) (api.APIProject, error) {
+	m.record("Projects.GetData", id)
+	if m.GetDataFunc != nil {
+		return m.GetDataFunc(ctx, id)
+	}
+	return api.APIProject{}, notStubbed("Projects.GetData")
+}
+
+// Update implements resources.ProjectsService.
+func (m *Projects) Update(ctx context.Context, options resources.UpdateProjectOptions) (api.RESTPatchUpdateProjectData, error) {
+	m.record("Projects.Update", options)
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, options)
+	}
+	return api.RESTPatchUpdateProjectData{}, notStubbed("Projects.Update")
+}
+
+// List implements resources.ProjectsService.
+func (m *Projects) List(ctx context.Context, query *api.RESTGetListProjectsQueryParams) (api.RESTGetListProjectsData, error) {
+	m.record("Projects.List", query)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, query)
+	}
+	return api.RESTGetListProjectsData{}, notStubbed("Projects.List")
+}
+
+// All implements resources.ProjectsService.
+func (m *Projects) All(ctx context.Context, options *resources.PaginateOptions) iter.Seq2[api.APIProject, error] {
+	m.record("Projects.All", options)
+	if m.AllFunc != nil {
+		return m.AllFunc(ctx, options)
+	}
+	return notStubbedSeq[api.APIProject]("Projects.All")
+}
+
+// Payments is a mock resources.PaymentsService. Each method records the call and
+// runs the matching func field, or returns ErrNotStubbed when it is nil.
+type Payments struct {
+	Recorder
+
+	BalanceFunc         func(ctx context.Context, project string) (api.RESTGetBalanceData, error)
+	UsageFunc           func(ctx context.Context, project string, query *api.RESTGetUsageQueryParams) (api.RESTGetUsageData, error)
+	TransactionsFunc    func(ctx context.Context, project string, query *api.RESTGetListTransactionsQueryParams) (api.RESTGetListTransactionsData, error)
+	AllTransactionsFunc func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APITransaction, error]
+	InvoicesFunc        func(ctx context.Context, project string, query *api.RESTGetListInvoicesQueryParams) (api.RESTGetListInvoicesData, error)
+	AllInvoicesFunc     func(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIInvoice, error]
+	InvoiceFunc         func(ctx context.Context, id, project string) (api.RESTGetInvoiceData, error)
+}
+
+var _ resources.PaymentsService = (*Payments)(nil)
+
+// Balance implements resources.PaymentsService.
+func (m *Payments) Balance(ctx context.Context, project string) (api.RESTGetBalanceData, error) {
+	m.record("Payments.Balance", project)
+	if m.BalanceFunc != nil {
+		return m.BalanceFunc(ctx, project)
+	}
+	return api.RESTGetBalanceData{}, notStubbed("Payments.Balance")
+}
+
+// Usage implements resources.PaymentsService.
+func (m *Payments) Usage(ctx context.Context, project string, query *api.RESTGetUsageQueryParams) (api.RESTGetUsageData, error) {
+	m.record("Payments.Usage", project, query)
+	if m.UsageFunc != nil {
+		return m.UsageFunc(ctx, project, query)
+	}
+	return api.RESTGetUsageData{}, notStubbed("Payments.Usage")
+}
+
+// Transactions implements resources.PaymentsService.
+func (m *Payments) Transactions(ctx context.Context, project string, query *api.RESTGetListTransactionsQueryParams) (api.RESTGetListTransactionsData, error) {
+	m.record("Payments.Transactions", project, query)
+	if m.TransactionsFunc != nil {
+		return m.TransactionsFunc(ctx, project, query)
+	}
+	return api.RESTGetListTransactionsData{}, notStubbed("Payments.Transactions")
+}
+
+// AllTransactions implements resources.PaymentsService.
+func (m *Payments) AllTransactions(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APITransaction, error] {
+	m.record("Payments.AllTransactions", project, options)
+	if m.AllTransactionsFunc != nil {
+		return m.AllTransactionsFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APITransaction]("Payments.AllTransactions")
+}
+
+// Invoices implements resources.PaymentsService.
+func (m *Payments) Invoices(ctx context.Context, project string, query *api.RESTGetListInvoicesQueryParams) (api.RESTGetListInvoicesData, error) {
+	m.record("Payments.Invoices", project, query)
+	if m.InvoicesFunc != nil {
+		return m.InvoicesFunc(ctx, project, query)
+	}
+	return api.RESTGetListInvoicesData{}, notStubbed("Payments.Invoices")
+}
+
+// AllInvoices implements resources.PaymentsService.
+func (m *Payments) AllInvoices(ctx context.Context, project string, options *resources.PaginateOptions) iter.Seq2[api.APIInvoice, error] {
+	m.record("Payments.AllInvoices", project, options)
+	if m.AllInvoicesFunc != nil {
+		return m.AllInvoicesFunc(ctx, project, options)
+	}
+	return notStubbedSeq[api.APIInvoice]("Payments.AllInvoices")
+}
+
+// Invoice implements resources.PaymentsService.
+func (m *Payments) Invoice(ctx context.Context, id, project string) (api.RESTGetInvoiceData, error) {
+	m.record("Payments.Invoice", id, project)
+	if m.InvoiceFunc != nil {
+		return m.InvoiceFunc(ctx, id, project)
+	}
+	return api.RESTGetInvoiceData{}, notStubbed("Payments.Invoice")
+}
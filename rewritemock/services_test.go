@@ -0,0 +1,51 @@
+package rewritemock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	rewrite "github.com/rewritetoday/golang"
+	"github.com/rewritetoday/golang/api"
+)
+
+func TestMocksRecordCallsAndRunStubs(t *testing.T) {
+	templates := &Templates{
+		GetDataFunc: func(_ context.Context, identifier, _ string) (api.APITemplate, error) {
+			return api.APITemplate{Name: identifier}, nil
+		},
+	}
+	services := rewrite.Services{Templates: templates}
+
+	template, err := services.Templates.GetData(context.Background(), "welcome_sms", "p1")
+	if err != nil || template.Name != "welcome_sms" {
+		t.Fatalf("unexpected stub result: %+v, %v", template, err)
+	}
+
+	if err := services.Templates.Delete(context.Background(), "t1", "p1"); !errors.Is(err, ErrNotStubbed) {
+		t.Fatalf("expected ErrNotStubbed, got %v", err)
+	}
+	for _, err := range services.Templates.All(context.Background(), "p1", nil) {
+		if !errors.Is(err, ErrNotStubbed) {
+			t.Fatalf("expected ErrNotStubbed from the iterator, got %v", err)
+		}
+	}
+
+	calls := templates.CallsTo("Templates.GetData")
+	if len(calls) != 1 || calls[0].Args[0] != "welcome_sms" || calls[0].Args[1] != "p1" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+	if got := len(templates.Calls()); got != 3 {
+		t.Fatalf("expected 3 recorded calls, got %d", got)
+	}
+}
+
+func TestClientServicesUseConcreteResources(t *testing.T) {
+	client, err := rewrite.New("rw_test")
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	if services := client.Services(); services.Templates != client.Templates || services.Payments != client.Payments {
+		t.Fatal("expected Services to expose the client resources")
+	}
+}
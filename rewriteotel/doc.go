@@ -0,0 +1,6 @@
+// Package rewriteotel instruments the Rewrite REST client with OpenTelemetry
+// tracing and metrics.
+//
+// It lives in its own module so the core SDK stays free of OpenTelemetry
+// dependencies.
+package rewriteotel
@@ -0,0 +1,25 @@
+module github.com/rewritetoday/golang/rewriteotel
+
+go 1.23.0
+
+require (
+	github.com/rewritetoday/golang v1.0.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+	go.opentelemetry.io/otel/sdk v1.38.0
+	go.opentelemetry.io/otel/sdk/metric v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
+)
+
+// The require names the latest published SDK. rest.CallObserver is not in a
+// tagged release yet, so builds inside this repository use the local SDK and
+// the require moves to the first release that ships it once that is tagged.
+replace github.com/rewritetoday/golang => ../
@@ -0,0 +1,190 @@
+package rewriteotel
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rewritetoday/golang/rest"
+)
+
+const instrumentationName = "github.com/rewritetoday/golang/rewriteotel"
+
+// Options configures New. Nil providers fall back to the OpenTelemetry globals.
+type Options struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	Propagators    propagation.TextMapPropagator
+}
+
+// Instrumentation records spans and metrics for REST calls.
+//
+// Each logical call (e.g. Webhooks.Update) gets an internal span, and each HTTP
+// attempt, including retries, gets a client child span. Trace context is
+// injected into outgoing request headers.
+type Instrumentation struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+
+	callDuration    metric.Float64Histogram
+	attemptDuration metric.Float64Histogram
+	retries         metric.Int64Counter
+	errors          metric.Int64Counter
+}
+
+// New creates an Instrumentation.
+func New(options Options) (*Instrumentation, error) {
+	tracerProvider := options.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	meterProvider := options.MeterProvider
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	propagators := options.Propagators
+	if propagators == nil {
+		propagators = otel.GetTextMapPropagator()
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+	callDuration, err := meter.Float64Histogram(
+		"rewrite.client.call.duration",
+		metric.WithDescription("Duration of logical Rewrite API calls, including retries."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	attemptDuration, err := meter.Float64Histogram(
+		"rewrite.client.attempt.duration",
+		metric.WithDescription("Duration of individual HTTP attempts."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	retries, err := meter.Int64Counter(
+		"rewrite.client.retries",
+		metric.WithDescription("Number of retried HTTP attempts."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errorCount, err := meter.Int64Counter(
+		"rewrite.client.errors",
+		metric.WithDescription("Number of logical calls that returned an error."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Instrumentation{
+		tracer:          tracerProvider.Tracer(instrumentationName),
+		propagators:     propagators,
+		callDuration:    callDuration,
+		attemptDuration: attemptDuration,
+		retries:         retries,
+		errors:          errorCount,
+	}, nil
+}
+
+// Instrument adds the call observer and attempt middleware to options.
+func (i *Instrumentation) Instrument(options *rest.Options) {
+	options.Observers = append(options.Observers, i.Observer())
+	options.Middleware = append([]rest.Middleware{i.Middleware()}, options.Middleware...)
+}
+
+// Observer returns a rest.CallObserver that opens a span per logical call.
+func (i *Instrumentation) Observer() rest.CallObserver {
+	return func(ctx context.Context, info rest.CallInfo) (context.Context, func(rest.CallResult)) {
+		start := time.Now()
+		ctx, span := i.tracer.Start(ctx, spanName(info), trace.WithSpanKind(trace.SpanKindInternal))
+		span.SetAttributes(callAttributes(info.Operation, info.Method, info.RouteTemplate)...)
+
+		return ctx, func(result rest.CallResult) {
+			attrs := callAttributes(info.Operation, info.Method, info.RouteTemplate)
+			if result.Status != 0 {
+				attrs = append(attrs, attribute.Int("http.response.status_code", result.Status))
+			}
+			retries := max(result.Attempts-1, 0)
+
+			span.SetAttributes(append(attrs, attribute.Int("rewrite.retry_count", retries))...)
+			if result.Err != nil {
+				span.RecordError(result.Err)
+				span.SetStatus(codes.Error, result.Err.Error())
+				i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+			span.End()
+
+			i.callDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+			if retries > 0 {
+				i.retries.Add(ctx, int64(retries), metric.WithAttributes(attrs...))
+			}
+		}
+	}
+}
+
+// Middleware returns a rest.Middleware that opens a span per HTTP attempt and
+// injects trace context into the outgoing headers.
+func (i *Instrumentation) Middleware() rest.Middleware {
+	return func(next rest.Handler) rest.Handler {
+		return func(req *http.Request) (*http.Response, error) {
+			info, _ := rest.RequestInfoFromContext(req.Context())
+			attrs := callAttributes(info.Operation, req.Method, info.RouteTemplate)
+
+			start := time.Now()
+			ctx, span := i.tracer.Start(req.Context(), "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindClient))
+			span.SetAttributes(attrs...)
+			span.SetAttributes(
+				attribute.String("server.address", req.URL.Hostname()),
+				attribute.Int("http.request.resend_count", info.Attempt),
+			)
+
+			req = req.WithContext(ctx)
+			i.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+			res, err := next(req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+				span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+				if res.StatusCode >= 400 {
+					span.SetStatus(codes.Error, strconv.Itoa(res.StatusCode))
+				}
+			}
+			span.End()
+
+			i.attemptDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+			return res, err
+		}
+	}
+}
+
+func spanName(info rest.CallInfo) string {
+	if info.Operation != "" {
+		return info.Operation
+	}
+	return info.Method + " " + info.RouteTemplate
+}
+
+func callAttributes(operation, method, routeTemplate string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", method),
+		attribute.String("url.template", routeTemplate),
+	}
+	if operation != "" {
+		attrs = append(attrs, attribute.String("rewrite.operation", operation))
+	}
+	return attrs
+}
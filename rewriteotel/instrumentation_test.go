@@ -0,0 +1,110 @@
+package rewriteotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	rewrite "github.com/rewritetoday/golang"
+)
+
+func TestInstrumentRecordsSpansAndMetrics(t *testing.T) {
+	attempts := 0
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		traceparent = r.Header.Get("Traceparent")
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := New(Options{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		Propagators:    propagation.TraceContext{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected instrumentation error: %v", err)
+	}
+
+	restOptions := &rewrite.RESTOptions{
+		BaseURL: server.URL,
+		Retry:   &rewrite.RetryOptions{Delay: func(int) time.Duration { return 0 }},
+	}
+	instrumentation.Instrument(restOptions)
+
+	client, err := rewrite.New(rewrite.RewriteOptions{Secret: "rw", Rest: restOptions})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+
+	name := "renamed"
+	_, err = client.Webhooks.Update(context.Background(), "42", rewrite.UpdateWebhookOptions{
+		Project:                    "7",
+		RESTPatchUpdateWebhookBody: rewrite.RESTPatchUpdateWebhookBody{Name: &name},
+	})
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("expected 2 attempt spans and 1 call span, got %d", len(ended))
+	}
+	call := ended[2]
+	if call.Name() != "Webhooks.Update" {
+		t.Fatalf("unexpected call span name: %s", call.Name())
+	}
+	for _, attempt := range ended[:2] {
+		if attempt.Parent().SpanID() != call.SpanContext().SpanID() {
+			t.Fatalf("attempt span %s is not a child of the call span", attempt.Name())
+		}
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range call.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["url.template"] != "/projects/{id}/webhooks/{webhookId}" || attrs["rewrite.retry_count"] != "1" || attrs["http.response.status_code"] != "200" {
+		t.Fatalf("unexpected call attributes: %v", attrs)
+	}
+	if traceparent == "" {
+		t.Fatal("expected trace context to be propagated")
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	found := map[string]bool{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = true
+			if m.Name == "rewrite.client.retries" {
+				sum := m.Data.(metricdata.Sum[int64])
+				if sum.DataPoints[0].Value != 1 {
+					t.Fatalf("expected 1 retry, got %d", sum.DataPoints[0].Value)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"rewrite.client.call.duration", "rewrite.client.attempt.duration", "rewrite.client.retries"} {
+		if !found[name] {
+			t.Fatalf("missing metric %s in %v", name, found)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package rewritetest
+
+import (
+	"testing"
+	"time"
+
+	rewrite "github.com/rewritetoday/golang"
+	"github.com/rewritetoday/golang/rest"
+)
+
+// NewClient starts a fake server and returns a client bound to it.
+//
+// The client uses the seeded project as DefaultProject and retries without
+// backoff delays, so injected faults do not slow tests down.
+func NewClient(t testing.TB) (*rewrite.Client, *Server) {
+	t.Helper()
+	server := NewServer(t)
+	return server.NewClient(t, nil), server
+}
+
+// NewClient returns a client for the server. Secret, BaseURL and, when empty,
+// DefaultProject are always set; other options are kept.
+func (s *Server) NewClient(t testing.TB, options *rewrite.RewriteOptions) *rewrite.Client {
+	t.Helper()
+
+	resolved := rewrite.RewriteOptions{}
+	if options != nil {
+		resolved = *options
+	}
+	resolved.Secret = Secret
+	if resolved.DefaultProject == "" {
+		resolved.DefaultProject = s.ProjectID
+	}
+
+	restOptions := rest.Options{}
+	if resolved.Rest != nil {
+		restOptions = *resolved.Rest
+	}
+	restOptions.BaseURL = s.BaseURL()
+	if restOptions.Retry == nil {
+		restOptions.Retry = &rest.RetryOptions{Delay: func(int) time.Duration { return 0 }}
+	}
+	resolved.Rest = &restOptions
+
+	client, err := rewrite.New(resolved)
+	if err != nil {
+		t.Fatalf("rewritetest: %v", err)
+	}
+	return client
+}
@@ -0,0 +1,15 @@
+// Package rewritetest provides an in-memory fake of the Rewrite API for tests.
+//
+// The fake keeps projects, templates, webhooks, API keys, messages and billing
+// data in memory, serves every route in api.Routes with the standard response
+// envelope and cursors, and answers bad input with validation errors shaped
+// like the real API:
+//
+//	client, server := rewritetest.NewClient(t)
+//	server.Inject(rewritetest.Fault{Status: http.StatusServiceUnavailable, Times: 1})
+//
+//	created, err := client.Templates.Create(ctx, rewrite.CreateTemplateOptions{...})
+//	server.AssertRequested(t, http.MethodPost, "/projects/{id}/templates")
+//
+// Faults inject error statuses, Retry-After headers and latency for matching requests.
+package rewritetest
@@ -0,0 +1,703 @@
+package rewritetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+var (
+	templateNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+	phonePattern        = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+)
+
+// maxContentLength is the longest template or message content accepted by
+// strict template rules.
+const maxContentLength = 1600
+
+type project struct {
+	info         api.APIProject
+	templates    []api.APITemplate
+	webhooks     []api.APIWebhook
+	apiKeys      []api.APIAPIKey
+	messages     []api.APIMessage
+	balance      api.APIBalance
+	usage        api.APIUsage
+	transactions []api.APITransaction
+	invoices     []api.APIInvoice
+}
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+	handle := func(pattern string, handler func(http.ResponseWriter, *http.Request, *project)) {
+		s.mux.HandleFunc(strings.Replace(pattern, " ", " "+basePath, 1), s.withProject(handler))
+	}
+
+	s.mux.HandleFunc("GET "+basePath+"/projects", s.listProjects)
+	handle("GET /projects/{id}", s.getProject)
+	handle("PATCH /projects/{id}", s.updateProject)
+
+	handle("GET /projects/{id}/templates", s.listTemplates)
+	handle("POST /projects/{id}/templates", s.createTemplate)
+	handle("GET /projects/{id}/templates/{templateId}", s.getTemplate)
+	handle("PATCH /projects/{id}/templates/{templateId}", s.updateTemplate)
+	handle("DELETE /projects/{id}/templates/{templateId}", s.deleteTemplate)
+
+	handle("GET /projects/{id}/webhooks", s.listWebhooks)
+	handle("POST /projects/{id}/webhooks", s.createWebhook)
+	handle("GET /projects/{id}/webhooks/{webhookId}", s.getWebhook)
+	handle("PATCH /projects/{id}/webhooks/{webhookId}", s.updateWebhook)
+	handle("DELETE /projects/{id}/webhooks/{webhookId}", s.deleteWebhook)
+
+	handle("GET /projects/{id}/api-keys", s.listAPIKeys)
+	handle("POST /projects/{id}/api-keys", s.createAPIKey)
+	handle("GET /projects/{id}/api-keys/{apiKeyId}", s.getAPIKey)
+	handle("PATCH /projects/{id}/api-keys/{apiKeyId}", s.updateAPIKey)
+	handle("DELETE /projects/{id}/api-keys/{apiKeyId}", s.deleteAPIKey)
+
+	handle("GET /projects/{id}/messages", s.listMessages)
+	handle("POST /projects/{id}/messages", s.sendMessage)
+	handle("GET /projects/{id}/messages/{messageId}", s.getMessage)
+	handle("POST /projects/{id}/messages/{messageId}/cancel", s.cancelMessage)
+
+	handle("GET /projects/{id}/payments/balance", s.getBalance)
+	handle("GET /projects/{id}/payments/usage", s.getUsage)
+	handle("GET /projects/{id}/payments/transactions", s.listTransactions)
+	handle("GET /projects/{id}/payments/invoices", s.listInvoices)
+	handle("GET /projects/{id}/payments/invoices/{invoiceId}", s.getInvoice)
+
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeNotFound(w, "route")
+	})
+}
+
+// withProject locks the server and resolves the {id} path value.
+func (s *Server) withProject(handler func(http.ResponseWriter, *http.Request, *project)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		p, ok := s.projects[r.PathValue("id")]
+		if !ok {
+			writeNotFound(w, "project")
+			return
+		}
+		handler(w, r, p)
+	}
+}
+
+// AddProject seeds a project. ID and CreatedAt are filled in when empty.
+func (s *Server) AddProject(info api.APIProject) api.APIProject {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if info.ID == "" {
+		info.ID = s.nextID()
+	}
+	if info.CreatedAt == "" {
+		info.CreatedAt = s.timestamp()
+	}
+	if info.SenderIDs == nil {
+		info.SenderIDs = []string{}
+	}
+	// The balance and currency are arbitrary seed values, not API defaults.
+	s.projects[string(info.ID)] = &project{
+		info:    info,
+		balance: api.APIBalance{Amount: 10000, Currency: "BRL", Credits: 1000, UpdatedAt: info.CreatedAt},
+		usage:   api.APIUsage{Currency: "BRL"},
+	}
+	s.order = append(s.order, string(info.ID))
+	return info
+}
+
+// SetBalance replaces the balance of a project.
+func (s *Server) SetBalance(projectID string, balance api.APIBalance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.projects[projectID]; ok {
+		p.balance = balance
+	}
+}
+
+// AddTransaction seeds a transaction. ID and CreatedAt are filled in when empty.
+func (s *Server) AddTransaction(projectID string, transaction api.APITransaction) api.APITransaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if transaction.ID == "" {
+		transaction.ID = s.nextID()
+	}
+	if transaction.CreatedAt == "" {
+		transaction.CreatedAt = s.timestamp()
+	}
+	if p, ok := s.projects[projectID]; ok {
+		p.transactions = append(p.transactions, transaction)
+	}
+	return transaction
+}
+
+// AddInvoice seeds an invoice. ID and CreatedAt are filled in when empty.
+func (s *Server) AddInvoice(projectID string, invoice api.APIInvoice) api.APIInvoice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if invoice.ID == "" {
+		invoice.ID = s.nextID()
+	}
+	if invoice.CreatedAt == "" {
+		invoice.CreatedAt = s.timestamp()
+	}
+	if p, ok := s.projects[projectID]; ok {
+		p.invoices = append(p.invoices, invoice)
+	}
+	return invoice
+}
+
+// SetMessageStatus simulates a delivery update. It reports whether the message exists.
+func (s *Server) SetMessageStatus(projectID, messageID string, status api.MessageStatus) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[projectID]
+	if !ok {
+		return false
+	}
+	i := indexByID(p.messages, messageID, func(m api.APIMessage) api.Snowflake { return m.ID })
+	if i < 0 {
+		return false
+	}
+	p.messages[i].Status = status
+	return true
+}
+
+// Templates returns the templates stored for a project.
+func (s *Server) Templates(projectID string) []api.APITemplate {
+	return snapshot(s, projectID, func(p *project) []api.APITemplate { return p.templates })
+}
+
+// Webhooks returns the webhooks stored for a project.
+func (s *Server) Webhooks(projectID string) []api.APIWebhook {
+	return snapshot(s, projectID, func(p *project) []api.APIWebhook { return p.webhooks })
+}
+
+// APIKeys returns the API keys stored for a project.
+func (s *Server) APIKeys(projectID string) []api.APIAPIKey {
+	return snapshot(s, projectID, func(p *project) []api.APIAPIKey { return p.apiKeys })
+}
+
+// Messages returns the messages stored for a project.
+func (s *Server) Messages(projectID string) []api.APIMessage {
+	return snapshot(s, projectID, func(p *project) []api.APIMessage { return p.messages })
+}
+
+func snapshot[T any](s *Server, projectID string, items func(*project) []T) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.projects[projectID]; ok {
+		return slices.Clone(items(p))
+	}
+	return nil
+}
+
+func indexByID[T any](items []T, id string, key func(T) api.Snowflake) int {
+	return slices.IndexFunc(items, func(item T) bool { return string(key(item)) == id })
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := make([]api.APIProject, len(s.order))
+	for i, id := range s.order {
+		projects[i] = s.projects[id].info
+	}
+	page(w, r, projects, func(p api.APIProject) api.Snowflake { return p.ID })
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, p *project) {
+	writeData(w, http.StatusOK, p.info)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, p *project) {
+	var body api.RESTPatchUpdateProjectBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	info := p.info
+	if body.Name != "" {
+		info.Name = body.Name
+	}
+	if body.SenderIDs != nil {
+		info.SenderIDs = body.SenderIDs
+	}
+	if body.DefaultSenderID != nil {
+		info.DefaultSenderID = body.DefaultSenderID
+	}
+	if body.ClearDefaultSenderID {
+		info.DefaultSenderID = nil
+	}
+
+	errs := validation{}
+	if info.DefaultSenderID != nil && !slices.Contains(info.SenderIDs, *info.DefaultSenderID) {
+		errs.add("defaultSenderId", "Expected one of the project sender IDs")
+	}
+	if errs.write(w) {
+		return
+	}
+
+	p.info = info
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.templates, func(t api.APITemplate) api.Snowflake { return t.ID })
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request, p *project) {
+	var body api.RESTPostCreateTemplateBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := validation{}
+	switch {
+	case body.Name == "":
+		errs.add("name", "Expected a template name")
+	case s.strictTemplates && !templateNamePattern.MatchString(body.Name):
+		errs.add("name", "Expected 1-64 lowercase letters, digits or underscores")
+	case slices.ContainsFunc(p.templates, func(t api.APITemplate) bool { return t.Name == body.Name }):
+		errs.add("name", "A template with this name already exists")
+	}
+	s.validateTemplateContent(errs, body.Content, body.Variables)
+	if errs.write(w) {
+		return
+	}
+
+	template := api.APITemplate{
+		ID:        s.nextID(),
+		Name:      body.Name,
+		Content:   &body.Content,
+		Variables: variablesOrEmpty(body.Variables),
+		CreatedAt: s.timestamp(),
+	}
+	p.templates = append(p.templates, template)
+	writeData(w, http.StatusCreated, api.APICreatedTemplate{ID: template.ID, CreatedAt: template.CreatedAt})
+}
+
+func (s *Server) findTemplate(p *project, identifier string) int {
+	return slices.IndexFunc(p.templates, func(t api.APITemplate) bool {
+		return string(t.ID) == identifier || t.Name == identifier
+	})
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request, p *project) {
+	i := s.findTemplate(p, r.PathValue("templateId"))
+	if i < 0 {
+		writeNotFound(w, "template")
+		return
+	}
+	writeData(w, http.StatusOK, p.templates[i])
+}
+
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.templates, r.PathValue("templateId"), func(t api.APITemplate) api.Snowflake { return t.ID })
+	if i < 0 {
+		writeNotFound(w, "template")
+		return
+	}
+
+	var body api.RESTPatchUpdateTemplateBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	template := p.templates[i]
+	content := *template.Content
+	if body.Content != "" {
+		content = body.Content
+	}
+	if body.Variables != nil {
+		template.Variables = body.Variables
+	}
+
+	errs := validation{}
+	s.validateTemplateContent(errs, content, template.Variables)
+	if errs.write(w) {
+		return
+	}
+
+	template.Content = &content
+	p.templates[i] = template
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) deleteTemplate(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.templates, r.PathValue("templateId"), func(t api.APITemplate) api.Snowflake { return t.ID })
+	if i < 0 {
+		writeNotFound(w, "template")
+		return
+	}
+	p.templates = slices.Delete(p.templates, i, i+1)
+	writeData(w, http.StatusOK, nil)
+}
+
+// validateTemplateContent must be called with s.mu held.
+func (s *Server) validateTemplateContent(errs validation, content string, variables []api.APITemplateVariable) {
+	switch {
+	case content == "":
+		errs.add("content", "Expected template content")
+	case s.strictTemplates && len([]rune(content)) > maxContentLength:
+		errs.add("content", fmt.Sprintf("Expected at most %d characters", maxContentLength))
+	}
+
+	seen := make(map[string]bool, len(variables))
+	for i, variable := range variables {
+		field := fmt.Sprintf("variables.%d.name", i)
+		switch {
+		case variable.Name == "":
+			errs.add(field, "Expected a variable name")
+		case seen[variable.Name]:
+			errs.add(field, "Duplicate variable name")
+		}
+		seen[variable.Name] = true
+	}
+}
+
+func variablesOrEmpty(variables []api.APITemplateVariable) []api.APITemplateVariable {
+	if variables == nil {
+		return []api.APITemplateVariable{}
+	}
+	return variables
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.webhooks, func(h api.APIWebhook) api.Snowflake { return h.ID })
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request, p *project) {
+	var body api.RESTPostCreateWebhookBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := validation{}
+	validateEndpoint(errs, body.Endpoint)
+	validateEvents(errs, body.Events)
+	if errs.write(w) {
+		return
+	}
+
+	webhook := api.APIWebhook{
+		ID:        s.nextID(),
+		Endpoint:  body.Endpoint,
+		Events:    body.Events,
+		Status:    api.WebhookStatusActive,
+		CreatedAt: s.timestamp(),
+	}
+	if body.Name != "" {
+		webhook.Name = &body.Name
+	}
+	p.webhooks = append(p.webhooks, webhook)
+	writeData(w, http.StatusCreated, api.APICreatedWebhook{ID: webhook.ID})
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.webhooks, r.PathValue("webhookId"), func(h api.APIWebhook) api.Snowflake { return h.ID })
+	if i < 0 {
+		writeNotFound(w, "webhook")
+		return
+	}
+	writeData(w, http.StatusOK, p.webhooks[i])
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.webhooks, r.PathValue("webhookId"), func(h api.APIWebhook) api.Snowflake { return h.ID })
+	if i < 0 {
+		writeNotFound(w, "webhook")
+		return
+	}
+
+	var body api.RESTPatchUpdateWebhookBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	webhook := p.webhooks[i]
+	errs := validation{}
+	if body.Name != nil {
+		webhook.Name = body.Name
+	}
+	if body.Endpoint != "" {
+		validateEndpoint(errs, body.Endpoint)
+		webhook.Endpoint = body.Endpoint
+	}
+	if body.Events != nil {
+		validateEvents(errs, body.Events)
+		webhook.Events = body.Events
+	}
+	if body.Status != "" {
+		if body.Status != api.WebhookStatusActive && body.Status != api.WebhookStatusInactive {
+			errs.add("status", "Expected ACTIVE or INACTIVE")
+		}
+		webhook.Status = body.Status
+	}
+	if errs.write(w) {
+		return
+	}
+
+	p.webhooks[i] = webhook
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.webhooks, r.PathValue("webhookId"), func(h api.APIWebhook) api.Snowflake { return h.ID })
+	if i < 0 {
+		writeNotFound(w, "webhook")
+		return
+	}
+	p.webhooks = slices.Delete(p.webhooks, i, i+1)
+	writeData(w, http.StatusOK, nil)
+}
+
+var webhookEvents = []api.WebhookEventType{
+	api.WebhookEventTypeSMSQueued,
+	api.WebhookEventTypeSMSDelivered,
+	api.WebhookEventTypeSMSScheduled,
+	api.WebhookEventTypeSMSFailed,
+	api.WebhookEventTypeSMSCanceled,
+}
+
+func validateEndpoint(errs validation, endpoint string) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		errs.add("endpoint", "Expected an absolute http or https URL")
+	}
+}
+
+func validateEvents(errs validation, events []api.WebhookEventType) {
+	if len(events) == 0 {
+		errs.add("events", "Expected at least one event")
+		return
+	}
+	for i, event := range events {
+		if !slices.Contains(webhookEvents, event) {
+			errs.add(fmt.Sprintf("events.%d", i), fmt.Sprintf("Unknown event %q", event))
+		}
+	}
+}
+
+func (s *Server) listAPIKeys(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.apiKeys, func(k api.APIAPIKey) api.Snowflake { return k.ID })
+}
+
+func (s *Server) createAPIKey(w http.ResponseWriter, r *http.Request, p *project) {
+	var body api.RESTPostCreateAPIKeyBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := validation{}
+	if strings.TrimSpace(body.Name) == "" {
+		errs.add("name", "Expected a name")
+	}
+	if err := api.Scopes(body.Scopes).Validate(); err != nil {
+		errs.add("scopes", err.Error())
+	}
+	if errs.write(w) {
+		return
+	}
+
+	secret := make([]byte, 24)
+	_, _ = rand.Read(secret)
+	key := "rw_" + hex.EncodeToString(secret)
+
+	scopes := body.Scopes
+	if scopes == nil {
+		scopes = []api.APIKeyScope{}
+	}
+	created := api.APIAPIKey{
+		ID:        s.nextID(),
+		Name:      body.Name,
+		Prefix:    key[:10],
+		Scopes:    scopes,
+		CreatedAt: s.timestamp(),
+	}
+	p.apiKeys = append(p.apiKeys, created)
+	writeData(w, http.StatusCreated, api.APICreatedAPIKey{ID: created.ID, Key: key, CreatedAt: created.CreatedAt})
+}
+
+func (s *Server) getAPIKey(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.apiKeys, r.PathValue("apiKeyId"), func(k api.APIAPIKey) api.Snowflake { return k.ID })
+	if i < 0 {
+		writeNotFound(w, "API key")
+		return
+	}
+	writeData(w, http.StatusOK, p.apiKeys[i])
+}
+
+func (s *Server) updateAPIKey(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.apiKeys, r.PathValue("apiKeyId"), func(k api.APIAPIKey) api.Snowflake { return k.ID })
+	if i < 0 {
+		writeNotFound(w, "API key")
+		return
+	}
+
+	var body api.RESTPatchUpdateAPIKeyBody
+	if !decode(w, r, &body) {
+		return
+	}
+	if err := api.Scopes(body.Scopes).Validate(); err != nil {
+		validation{"scopes": err.Error()}.write(w)
+		return
+	}
+
+	if body.Name != "" {
+		p.apiKeys[i].Name = body.Name
+	}
+	if body.Scopes != nil {
+		p.apiKeys[i].Scopes = body.Scopes
+	}
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) deleteAPIKey(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.apiKeys, r.PathValue("apiKeyId"), func(k api.APIAPIKey) api.Snowflake { return k.ID })
+	if i < 0 {
+		writeNotFound(w, "API key")
+		return
+	}
+	p.apiKeys = slices.Delete(p.apiKeys, i, i+1)
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.messages, func(m api.APIMessage) api.Snowflake { return m.ID })
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request, p *project) {
+	var body api.RESTPostSendMessageBody
+	if !decode(w, r, &body) {
+		return
+	}
+
+	errs := validation{}
+	if !phonePattern.MatchString(body.To) {
+		errs.add("to", "Expected an E.164 phone number")
+	}
+
+	hasTemplate := body.Template != nil && body.Template.Identifier != ""
+	message := api.APIMessage{Status: api.MessageStatusQueued, To: body.To}
+	switch {
+	case body.Content == "" && !hasTemplate:
+		errs.add("content", "Expected either content or a template")
+	case body.Content != "" && hasTemplate:
+		errs.add("content", "Expected only one of content or template")
+	case hasTemplate:
+		i := s.findTemplate(p, body.Template.Identifier)
+		if i < 0 {
+			errs.add("template.identifier", "Unknown template")
+			break
+		}
+		template := p.templates[i]
+		message.Template = &template.ID
+		message.Variables = body.Template.Variables
+		message.Content = template.Render(body.Template.Variables).Content
+	default:
+		message.Content = body.Content
+	}
+	if s.strictTemplates && len([]rune(message.Content)) > maxContentLength {
+		errs.add("content", fmt.Sprintf("Expected at most %d characters", maxContentLength))
+	}
+
+	if body.ScheduledAt != "" {
+		scheduled, err := time.Parse(time.RFC3339, body.ScheduledAt)
+		switch {
+		case err != nil:
+			errs.add("scheduledAt", "Expected an RFC 3339 timestamp")
+		case scheduled.After(s.now()):
+			message.Status = api.MessageStatusScheduled
+			message.ScheduledAt = &body.ScheduledAt
+		}
+	}
+	if errs.write(w) {
+		return
+	}
+
+	message.ID = s.nextID()
+	message.CreatedAt = s.timestamp()
+	p.messages = append(p.messages, message)
+
+	p.balance.Credits--
+	p.usage.Messages++
+	p.usage.Credits++
+
+	writeData(w, http.StatusCreated, api.APICreatedMessage{ID: message.ID, Status: message.Status, CreatedAt: message.CreatedAt})
+}
+
+func (s *Server) getMessage(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.messages, r.PathValue("messageId"), func(m api.APIMessage) api.Snowflake { return m.ID })
+	if i < 0 {
+		writeNotFound(w, "message")
+		return
+	}
+	writeData(w, http.StatusOK, p.messages[i])
+}
+
+func (s *Server) cancelMessage(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.messages, r.PathValue("messageId"), func(m api.APIMessage) api.Snowflake { return m.ID })
+	if i < 0 {
+		writeNotFound(w, "message")
+		return
+	}
+
+	status := p.messages[i].Status
+	if status != api.MessageStatusQueued && status != api.MessageStatusScheduled {
+		writeError(w, http.StatusConflict, "CONFLICT", "Message can no longer be canceled", nil)
+		return
+	}
+
+	p.messages[i].Status = api.MessageStatusCanceled
+	p.balance.Credits++
+	writeData(w, http.StatusOK, nil)
+}
+
+func (s *Server) getBalance(w http.ResponseWriter, r *http.Request, p *project) {
+	writeData(w, http.StatusOK, p.balance)
+}
+
+func (s *Server) getUsage(w http.ResponseWriter, r *http.Request, p *project) {
+	now := s.now().UTC()
+	usage := p.usage
+	usage.From = r.URL.Query().Get("from")
+	if usage.From == "" {
+		usage.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+	}
+	usage.To = r.URL.Query().Get("to")
+	if usage.To == "" {
+		usage.To = now.Format(time.DateOnly)
+	}
+	writeData(w, http.StatusOK, usage)
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.transactions, func(t api.APITransaction) api.Snowflake { return t.ID })
+}
+
+func (s *Server) listInvoices(w http.ResponseWriter, r *http.Request, p *project) {
+	page(w, r, p.invoices, func(i api.APIInvoice) api.Snowflake { return i.ID })
+}
+
+func (s *Server) getInvoice(w http.ResponseWriter, r *http.Request, p *project) {
+	i := indexByID(p.invoices, r.PathValue("invoiceId"), func(i api.APIInvoice) api.Snowflake { return i.ID })
+	if i < 0 {
+		writeNotFound(w, "invoice")
+		return
+	}
+	writeData(w, http.StatusOK, p.invoices[i])
+}
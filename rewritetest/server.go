@@ -0,0 +1,451 @@
+package rewritetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Secret is the API secret accepted by the fake server.
+const Secret = "rw_test_secret"
+
+// basePath mirrors the /v1 prefix of api.APIBaseURL.
+const basePath = "/v1"
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	// Path is the API route without the /v1 prefix or query, e.g. /projects/1/templates.
+	Path string
+	// RouteTemplate is Path with IDs replaced, e.g. /projects/{id}/templates.
+	RouteTemplate string
+	Query         url.Values
+	Header        http.Header
+	Body          []byte
+}
+
+// Fault makes the server misbehave for matching requests.
+type Fault struct {
+	// Method matches the HTTP method. Empty matches every method.
+	Method string
+	// RouteTemplate matches Request.RouteTemplate. Empty matches every route.
+	RouteTemplate string
+	// Status is sent instead of running the handler. Zero only applies Latency.
+	Status int
+	// RetryAfter sets the Retry-After header on the injected response.
+	RetryAfter time.Duration
+	// Latency delays the response, or until the client gives up.
+	Latency time.Duration
+	// Times is the number of requests affected. Zero affects every matching request.
+	Times int
+}
+
+type fault struct {
+	Fault
+	used int
+}
+
+// Server is a stateful in-memory Rewrite API.
+type Server struct {
+	*httptest.Server
+
+	// ProjectID is the project seeded by NewServer.
+	ProjectID string
+
+	mu          sync.Mutex
+	seq         uint64
+	now         func() time.Time
+	mux         *http.ServeMux
+	projects    map[string]*project
+	order       []string
+	faults      []*fault
+	requests    []Request
+	idempotency map[string]*recorded
+
+	strictTemplates bool
+}
+
+// recorded is the response to an idempotency key. done is closed once the
+// first request with the key has finished, and stored reports whether its
+// response was kept.
+type recorded struct {
+	done   chan struct{}
+	stored bool
+	status int
+	body   []byte
+}
+
+// NewServer starts a fake server with one seeded project and closes it when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		seq:         1_000_000_000_000_000_000,
+		now:         time.Now,
+		projects:    make(map[string]*project),
+		idempotency: make(map[string]*recorded),
+	}
+	s.ProjectID = string(s.AddProject(api.APIProject{Name: "Test project"}).ID)
+	s.routes()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL returns the URL to use as RESTOptions.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + basePath
+}
+
+// Inject adds a fault. Faults are checked in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{Fault: f})
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetStrictTemplateRules makes the server reject template names outside
+// [a-z0-9_]{1,64} and template or message content over 1600 characters. The
+// rules are off by default because the API does not document them; they match
+// the SDK's opt-in StrictValidation.
+func (s *Server) SetStrictTemplateRules(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strictTemplates = enabled
+}
+
+// Requests returns every request received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// RequestsTo returns the requests matching method and route template.
+func (s *Server) RequestsTo(method, routeTemplate string) []Request {
+	var out []Request
+	for _, request := range s.Requests() {
+		if request.Method == method && request.RouteTemplate == routeTemplate {
+			out = append(out, request)
+		}
+	}
+	return out
+}
+
+// LastRequest returns the most recent request.
+func (s *Server) LastRequest() (Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}, false
+	}
+	return s.requests[len(s.requests)-1], true
+}
+
+// AssertRequested fails the test unless a request matched method and route template.
+// It returns the last matching request.
+func (s *Server) AssertRequested(t testing.TB, method, routeTemplate string) Request {
+	t.Helper()
+	matches := s.RequestsTo(method, routeTemplate)
+	if len(matches) == 0 {
+		t.Fatalf("expected a %s %s request, got %s", method, routeTemplate, s.describeRequests())
+		return Request{}
+	}
+	return matches[len(matches)-1]
+}
+
+// AssertRequestCount fails the test unless exactly n requests matched method and route template.
+func (s *Server) AssertRequestCount(t testing.TB, method, routeTemplate string, n int) {
+	t.Helper()
+	if got := len(s.RequestsTo(method, routeTemplate)); got != n {
+		t.Fatalf("expected %d %s %s requests, got %d: %s", n, method, routeTemplate, got, s.describeRequests())
+	}
+}
+
+// AssertNotRequested fails the test if any request matched method and route template.
+func (s *Server) AssertNotRequested(t testing.TB, method, routeTemplate string) {
+	t.Helper()
+	s.AssertRequestCount(t, method, routeTemplate, 0)
+}
+
+func (s *Server) describeRequests() string {
+	requests := s.Requests()
+	if len(requests) == 0 {
+		return "no requests"
+	}
+	lines := make([]string, len(requests))
+	for i, request := range requests {
+		lines[i] = request.Method + " " + request.Path
+	}
+	return strings.Join(lines, ", ")
+}
+
+// serve records the request, applies faults and idempotency, then routes it.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	path := strings.TrimPrefix(r.URL.Path, basePath)
+	request := Request{
+		Method:        r.Method,
+		Path:          path,
+		RouteTemplate: api.RouteTemplate(path),
+		Query:         r.URL.Query(),
+		Header:        r.Header.Clone(),
+		Body:          body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	injected := s.matchFault(request)
+	s.mu.Unlock()
+
+	if injected != nil {
+		if injected.Latency > 0 {
+			select {
+			case <-time.After(injected.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if injected.Status != 0 {
+			if injected.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((injected.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, injected.Status, faultCode(injected.Status), http.StatusText(injected.Status), nil)
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+Secret {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid API key", nil)
+		return
+	}
+
+	key := r.Header.Get("Idempotency-Key")
+	if r.Method == http.MethodPost && key != "" {
+		s.serveIdempotent(w, r, key)
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// serveIdempotent runs the first request with key and replays its response to
+// later ones. Requests arriving while the first is in flight wait for it, so
+// the handler runs once per key. 5xx responses are not kept.
+func (s *Server) serveIdempotent(w http.ResponseWriter, r *http.Request, key string) {
+	for {
+		s.mu.Lock()
+		entry, ok := s.idempotency[key]
+		if !ok {
+			entry = &recorded{done: make(chan struct{})}
+			s.idempotency[key] = entry
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			break
+		}
+		select {
+		case <-entry.done:
+		case <-r.Context().Done():
+			return
+		}
+		if entry.stored {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(entry.status)
+			_, _ = w.Write(entry.body)
+			return
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	s.mux.ServeHTTP(recorder, r)
+
+	s.mu.Lock()
+	entry := s.idempotency[key]
+	if recorder.Code < http.StatusInternalServerError {
+		entry.stored = true
+		entry.status = recorder.Code
+		entry.body = recorder.Body.Bytes()
+	} else {
+		delete(s.idempotency, key)
+	}
+	close(entry.done)
+	s.mu.Unlock()
+
+	for name, values := range recorder.Header() {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(recorder.Code)
+	_, _ = w.Write(recorder.Body.Bytes())
+}
+
+// matchFault must be called with s.mu held.
+func (s *Server) matchFault(request Request) *Fault {
+	for _, f := range s.faults {
+		if f.Method != "" && f.Method != request.Method {
+			continue
+		}
+		if f.RouteTemplate != "" && f.RouteTemplate != request.RouteTemplate {
+			continue
+		}
+		if f.Times > 0 && f.used >= f.Times {
+			continue
+		}
+		f.used++
+		return &f.Fault
+	}
+	return nil
+}
+
+func faultCode(status int) string {
+	switch {
+	case status == http.StatusTooManyRequests:
+		return "RATE_LIMITED"
+	case status >= http.StatusInternalServerError:
+		return "INTERNAL_ERROR"
+	default:
+		return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+	}
+}
+
+// nextID must be called with s.mu held.
+func (s *Server) nextID() api.Snowflake {
+	s.seq++
+	return api.Snowflake(strconv.FormatUint(s.seq, 10))
+}
+
+func (s *Server) timestamp() string {
+	return s.now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeData(w http.ResponseWriter, status int, data any) {
+	writeJSON(w, status, api.APIResponse[any]{OK: true, Data: data})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, validation *api.APIValidationError) {
+	writeJSON(w, status, api.APIResponse[any]{Code: code, Message: message, Errors: validation})
+}
+
+func writeNotFound(w http.ResponseWriter, resource string) {
+	writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("Unknown %s", resource), nil)
+}
+
+// validation collects field errors and writes them as a 400 response.
+type validation map[string]any
+
+func (v validation) add(field, message string) {
+	if _, ok := v[field]; !ok {
+		v[field] = message
+	}
+}
+
+func (v validation) write(w http.ResponseWriter) bool {
+	if len(v) == 0 {
+		return false
+	}
+	writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Validation failed", &api.APIValidationError{
+		Message:  "Validation failed",
+		Detailed: v,
+	})
+	return true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, out any) bool {
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		writeError(w, http.StatusBadRequest, "VALIDATION_ERROR", "Invalid JSON body", &api.APIValidationError{
+			Message:  "Invalid JSON body",
+			Detailed: map[string]any{"body": err.Error()},
+		})
+		return false
+	}
+	return true
+}
+
+// page applies cursor pagination to items sorted by ascending ID.
+func page[T any](w http.ResponseWriter, r *http.Request, items []T, id func(T) api.Snowflake) {
+	query := r.URL.Query()
+
+	limit := 15
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 100 {
+			validation{"limit": "Expected a number between 1 and 100"}.write(w)
+			return
+		}
+		limit = parsed
+	}
+
+	var selected []T
+	persist := false
+	if before := query.Get("before"); before != "" && query.Get("after") == "" {
+		var older []T
+		for _, item := range items {
+			if compareID(id(item), api.Snowflake(before)) < 0 {
+				older = append(older, item)
+			}
+		}
+		start := max(len(older)-limit, 0)
+		selected = older[start:]
+		persist = start > 0
+	} else {
+		after := api.Snowflake(query.Get("after"))
+		var newer []T
+		for _, item := range items {
+			if after == "" || compareID(id(item), after) > 0 {
+				newer = append(newer, item)
+			}
+		}
+		selected = newer[:min(limit, len(newer))]
+		persist = len(newer) > limit
+	}
+
+	cursor := &api.Cursor{Persist: persist}
+	if len(selected) > 0 {
+		next := id(selected[len(selected)-1])
+		if query.Get("before") != "" && query.Get("after") == "" {
+			next = id(selected[0])
+		}
+		cursor.Next = &next
+	}
+	if selected == nil {
+		selected = []T{}
+	}
+	writeJSON(w, http.StatusOK, api.APIResponse[[]T]{OK: true, Data: selected, Cursor: cursor})
+}
+
+// compareID orders snowflakes numerically.
+func compareID(a, b api.Snowflake) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(string(a), string(b))
+}
@@ -0,0 +1,259 @@
+package rewritetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	rewrite "github.com/rewritetoday/golang"
+)
+
+func TestTemplatesLifecycle(t *testing.T) {
+	client, server := NewClient(t)
+	ctx := context.Background()
+
+	created, err := rewrite.Data(client.Templates.Create(ctx, rewrite.CreateTemplateOptions{
+		RESTPostCreateTemplateBody: rewrite.RESTPostCreateTemplateBody{
+			Name:      "welcome_sms",
+			Content:   "Hi {{name}}",
+			Variables: []rewrite.APITemplateVariable{{Name: "name", Fallback: "there"}},
+		},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	template, err := client.Templates.GetData(ctx, "welcome_sms", "")
+	if err != nil || template.ID != created.ID || *template.Content != "Hi {{name}}" {
+		t.Fatalf("unexpected template: %+v, %v", template, err)
+	}
+
+	_, err = client.Templates.Update(ctx, string(created.ID), rewrite.UpdateTemplateOptions{
+		RESTPatchUpdateTemplateBody: rewrite.RESTPatchUpdateTemplateBody{Content: "Hello {{name}}"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+	if got := server.Templates(server.ProjectID); len(got) != 1 || *got[0].Content != "Hello {{name}}" {
+		t.Fatalf("unexpected stored templates: %+v", got)
+	}
+
+	if err := client.Templates.Delete(ctx, string(created.ID), ""); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if _, err := client.Templates.Get(ctx, string(created.ID), ""); !errors.Is(err, rewrite.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	server.AssertRequested(t, http.MethodPatch, "/projects/{id}/templates/{templateId}")
+	server.AssertRequestCount(t, http.MethodDelete, "/projects/{id}/templates/{templateId}", 1)
+}
+
+func TestValidationErrors(t *testing.T) {
+	client, _ := NewClient(t)
+
+	_, err := client.Webhooks.Create(context.Background(), rewrite.CreateWebhookOptions{
+		RESTPostCreateWebhookBody: rewrite.RESTPostCreateWebhookBody{Endpoint: "not a url"},
+	})
+
+	var httpErr *rewrite.HTTPError
+	if !errors.As(err, &httpErr) || !errors.Is(err, rewrite.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	fields := httpErr.FieldErrors()
+	if fields["endpoint"] == nil || fields["events"] == nil {
+		t.Fatalf("unexpected field errors: %#v", fields)
+	}
+}
+
+func TestProjectUpdateClearsSenders(t *testing.T) {
+	client, server := NewClient(t)
+	ctx := context.Background()
+	sender := "ACME"
+	project := server.AddProject(rewrite.APIProject{Name: "Senders", SenderIDs: []string{sender}, DefaultSenderID: &sender})
+
+	_, err := client.Project(string(project.ID)).Update(ctx, rewrite.RESTPatchUpdateProjectBody{SenderIDs: []string{}})
+	if !errors.Is(err, rewrite.ErrValidation) {
+		t.Fatalf("expected the default sender to block clearing the list, got %v", err)
+	}
+
+	_, err = client.Project(string(project.ID)).Update(ctx, rewrite.RESTPatchUpdateProjectBody{SenderIDs: []string{}, ClearDefaultSenderID: true})
+	if err != nil {
+		t.Fatalf("unexpected update error: %v", err)
+	}
+	request := server.AssertRequested(t, http.MethodPatch, "/projects/{id}")
+	if string(request.Body) != `{"senderIds":[],"defaultSenderId":null}` {
+		t.Fatalf("unexpected body: %s", request.Body)
+	}
+
+	updated, err := client.Projects.GetData(ctx, string(project.ID))
+	if err != nil || len(updated.SenderIDs) != 0 || updated.DefaultSenderID != nil || updated.Name != "Senders" {
+		t.Fatalf("unexpected project: %+v, %v", updated, err)
+	}
+}
+
+func TestTemplateValidationRunsBeforeTheRequest(t *testing.T) {
+	client, server := NewClient(t)
+	ctx := context.Background()
+	options := rewrite.CreateTemplateOptions{
+		RESTPostCreateTemplateBody: rewrite.RESTPostCreateTemplateBody{
+			Name:    "welcome",
+			Content: "Hi {{name}}",
+		},
+	}
+
+	_, err := client.Templates.Create(ctx, options)
+	var local *rewrite.ValidationError
+	if !errors.As(err, &local) || !errors.Is(err, rewrite.ErrValidation) || local.Operation != "Templates.Create" {
+		t.Fatalf("expected a local validation error, got %v", err)
+	}
+	if local.FieldErrors()["content"] != "Expected variables for {{name}}" {
+		t.Fatalf("unexpected field errors: %v", local.FieldErrors())
+	}
+	server.AssertNotRequested(t, http.MethodPost, "/projects/{id}/templates")
+
+	options.Name = "Welcome"
+	options.Variables = []rewrite.APITemplateVariable{{Name: "name"}}
+	options.StrictValidation = true
+	_, err = client.Templates.Create(ctx, options)
+	if !errors.As(err, &local) || local.FieldErrors()["name"] == nil {
+		t.Fatalf("expected strict validation to reject the name, got %v", err)
+	}
+	server.AssertNotRequested(t, http.MethodPost, "/projects/{id}/templates")
+
+	options.StrictValidation = false
+	options.SkipValidation = true
+	server.SetStrictTemplateRules(true)
+	_, err = client.Templates.Create(ctx, options)
+	var remote *rewrite.HTTPError
+	if !errors.As(err, &remote) || remote.FieldErrors()["name"] == nil {
+		t.Fatalf("expected the strict server to reject the name, got %v", err)
+	}
+
+	server.SetStrictTemplateRules(false)
+	if _, err := client.Templates.Create(ctx, options); err != nil {
+		t.Fatalf("expected the default server to accept the name, got %v", err)
+	}
+	server.AssertRequestCount(t, http.MethodPost, "/projects/{id}/templates", 2)
+}
+
+func TestPaginationAcrossPages(t *testing.T) {
+	client, server := NewClient(t)
+	ctx := context.Background()
+
+	for range 5 {
+		_, err := client.Messages.Send(ctx, rewrite.SendMessageOptions{
+			RESTPostSendMessageBody: rewrite.RESTPostSendMessageBody{To: "+5511999999999", Content: "hi"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected send error: %v", err)
+		}
+	}
+
+	var count int
+	for _, err := range client.Messages.All(ctx, "", &rewrite.PaginateOptions{RESTCursorOptions: rewrite.RESTCursorOptions{Limit: 2}}) {
+		if err != nil {
+			t.Fatalf("unexpected iteration error: %v", err)
+		}
+		count++
+	}
+	if count != 5 {
+		t.Fatalf("expected 5 messages, got %d", count)
+	}
+
+	usage, err := rewrite.Data(client.Project(server.ProjectID).Payments.Usage(ctx, nil))
+	if err != nil || usage.Messages != 5 {
+		t.Fatalf("unexpected usage: %+v, %v", usage, err)
+	}
+	if _, err := client.Project("404").Payments.Balance(ctx); !errors.Is(err, rewrite.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown project, got %v", err)
+	}
+}
+
+func TestFaultsAreRetriedWithoutDuplicates(t *testing.T) {
+	client, server := NewClient(t)
+	server.Inject(Fault{Method: http.MethodPost, RouteTemplate: "/projects/{id}/messages", Status: http.StatusServiceUnavailable, Times: 2})
+
+	sent, err := rewrite.Data(client.Messages.Send(context.Background(), rewrite.SendMessageOptions{
+		RESTPostSendMessageBody: rewrite.RESTPostSendMessageBody{To: "+5511999999999", Content: "hi"},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected send error: %v", err)
+	}
+
+	server.AssertRequestCount(t, http.MethodPost, "/projects/{id}/messages", 3)
+	requests := server.RequestsTo(http.MethodPost, "/projects/{id}/messages")
+	if key := requests[0].Header.Get("Idempotency-Key"); key == "" || requests[2].Header.Get("Idempotency-Key") != key {
+		t.Fatal("expected the idempotency key to be reused across retries")
+	}
+	if messages := server.Messages(server.ProjectID); len(messages) != 1 || messages[0].ID != sent.ID {
+		t.Fatalf("unexpected stored messages: %+v", messages)
+	}
+}
+
+func TestConcurrentRequestsShareAnIdempotencyKey(t *testing.T) {
+	client, server := NewClient(t)
+	// Slow every handler down so the requests overlap.
+	routes := server.mux
+	server.mux = http.NewServeMux()
+	server.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		routes.ServeHTTP(w, r)
+	})
+	var wg sync.WaitGroup
+	ids := make([]rewrite.Snowflake, 20)
+	errs := make([]error, len(ids))
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sent, err := rewrite.Data(client.Messages.Send(context.Background(), rewrite.SendMessageOptions{
+				IdempotencyKey:          "send-once",
+				RESTPostSendMessageBody: rewrite.RESTPostSendMessageBody{To: "+5511999999999", Content: "hi"},
+			}))
+			ids[i], errs[i] = sent.ID, err
+		}()
+	}
+	wg.Wait()
+
+	for i := range ids {
+		if errs[i] != nil || ids[i] != ids[0] {
+			t.Fatalf("expected every request to get the same message, got %q, %v", ids[i], errs[i])
+		}
+	}
+	if messages := server.Messages(server.ProjectID); len(messages) != 1 {
+		t.Fatalf("expected one stored message, got %d", len(messages))
+	}
+}
+
+func TestRateLimitAndLatencyFaults(t *testing.T) {
+	client, server := NewClient(t)
+	ctx := context.Background()
+
+	server.Inject(Fault{Status: http.StatusTooManyRequests})
+	if _, err := client.Templates.List(ctx, "", nil); !errors.Is(err, rewrite.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+
+	server.ClearFaults()
+	server.Inject(Fault{Latency: time.Second})
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Templates.List(ctx, "", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	server := NewServer(t)
+	client, err := rewrite.New(rewrite.RewriteOptions{Secret: "rw_wrong", Rest: &rewrite.RESTOptions{BaseURL: server.BaseURL()}})
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %v", err)
+	}
+	if _, err := client.Projects.Get(context.Background(), server.ProjectID); !errors.Is(err, rewrite.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
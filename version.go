@@ -1,4 +1,4 @@
 package rewrite
 
 // Version is the current version of the Rewrite Go SDK.
-const Version = "1.0.0"
+const Version = "1.1.0"
@@ -0,0 +1,17 @@
+// Package webhooks verifies and decodes webhook deliveries sent by Rewrite.
+//
+// # Unconfirmed delivery format
+//
+// The API reference does not document how deliveries are signed or encoded, so
+// this package is modeled on assumptions until the API confirms them:
+//
+//   - the X-Rewrite-Signature and X-Rewrite-Timestamp headers;
+//   - signatures of the form "v1=<hex>", an HMAC-SHA256 of "<unix>.<payload>"
+//     keyed with the webhook secret;
+//   - the event envelope (id, type, project, createdAt and data) and the
+//     payload fields such as deliveredAt, scheduledAt, canceledAt and
+//     error.code and error.message.
+//
+// VerifyOptions can change the header names, the signature prefix and the
+// signed content if deliveries turn out to differ.
+package webhooks
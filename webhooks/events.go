@@ -0,0 +1,154 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// Event is implemented by every typed webhook event.
+type Event interface {
+	// EventType returns the webhook event type.
+	EventType() api.WebhookEventType
+	// Meta returns the fields shared by every event.
+	Meta() EventMeta
+}
+
+// EventMeta holds the envelope fields shared by every webhook event.
+// The envelope and payload shapes are unconfirmed; see the package docs.
+type EventMeta struct {
+	ID        api.Snowflake        `json:"id"`
+	Type      api.WebhookEventType `json:"type"`
+	Project   api.Snowflake        `json:"project"`
+	CreatedAt string               `json:"createdAt"`
+}
+
+// EventType implements Event.
+func (m EventMeta) EventType() api.WebhookEventType {
+	return m.Type
+}
+
+// Meta implements Event.
+func (m EventMeta) Meta() EventMeta {
+	return m
+}
+
+// MessageData describes the message an SMS event refers to.
+type MessageData struct {
+	ID       api.Snowflake     `json:"id"`
+	To       string            `json:"to"`
+	Status   api.MessageStatus `json:"status"`
+	Template *api.Snowflake    `json:"template"`
+}
+
+// SMSQueuedEvent is sent for sms.queued.
+type SMSQueuedEvent struct {
+	EventMeta
+	Data MessageData `json:"data"`
+}
+
+// SMSDeliveredEvent is sent for sms.delivered.
+type SMSDeliveredEvent struct {
+	EventMeta
+	Data SMSDeliveredData `json:"data"`
+}
+
+// SMSDeliveredData is the sms.delivered payload.
+type SMSDeliveredData struct {
+	MessageData
+	DeliveredAt string `json:"deliveredAt"`
+}
+
+// SMSScheduledEvent is sent for sms.scheduled.
+type SMSScheduledEvent struct {
+	EventMeta
+	Data SMSScheduledData `json:"data"`
+}
+
+// SMSScheduledData is the sms.scheduled payload.
+type SMSScheduledData struct {
+	MessageData
+	ScheduledAt string `json:"scheduledAt"`
+}
+
+// SMSFailedEvent is sent for sms.failed.
+type SMSFailedEvent struct {
+	EventMeta
+	Data SMSFailedData `json:"data"`
+}
+
+// SMSFailedData is the sms.failed payload.
+type SMSFailedData struct {
+	MessageData
+	Error SMSFailure `json:"error"`
+}
+
+// SMSFailure describes why delivery failed.
+type SMSFailure struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// SMSCanceledEvent is sent for sms.canceled.
+type SMSCanceledEvent struct {
+	EventMeta
+	Data SMSCanceledData `json:"data"`
+}
+
+// SMSCanceledData is the sms.canceled payload.
+type SMSCanceledData struct {
+	MessageData
+	CanceledAt string `json:"canceledAt"`
+}
+
+// UnknownEvent is returned for event types this SDK version does not know.
+type UnknownEvent struct {
+	EventMeta
+	Data json.RawMessage `json:"data"`
+}
+
+// ParseEvent decodes a webhook body into its typed event struct.
+//
+// Unknown event types are returned as *UnknownEvent so new events do not break older handlers.
+func ParseEvent(payload []byte) (Event, error) {
+	var meta EventMeta
+	if err := json.Unmarshal(payload, &meta); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	switch meta.Type {
+	case api.WebhookEventTypeSMSQueued:
+		event = &SMSQueuedEvent{}
+	case api.WebhookEventTypeSMSDelivered:
+		event = &SMSDeliveredEvent{}
+	case api.WebhookEventTypeSMSScheduled:
+		event = &SMSScheduledEvent{}
+	case api.WebhookEventTypeSMSFailed:
+		event = &SMSFailedEvent{}
+	case api.WebhookEventTypeSMSCanceled:
+		event = &SMSCanceledEvent{}
+	default:
+		event = &UnknownEvent{}
+	}
+
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ConstructEvent verifies the delivery signature and then parses the event.
+func ConstructEvent(payload []byte, headers http.Header, secret string) (Event, error) {
+	return ConstructEventWithOptions(payload, headers, secret, VerifyOptions{})
+}
+
+// ConstructEventWithOptions is ConstructEvent with custom verification options.
+func ConstructEventWithOptions(payload []byte, headers http.Header, secret string, options VerifyOptions) (Event, error) {
+	if err := VerifyWithOptions(payload, headers, secret, options); err != nil {
+		return nil, err
+	}
+	return ParseEvent(payload)
+}
@@ -0,0 +1,196 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+// DefaultMaxBodyBytes caps webhook bodies read by Router.
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// HandlerFunc handles a verified webhook event.
+// Returning an error replies with 500 so Rewrite redelivers the event.
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Middleware wraps the router's http.Handler.
+type Middleware func(next http.Handler) http.Handler
+
+// RouterOptions configures NewRouter.
+type RouterOptions struct {
+	// Secret is the webhook signing secret.
+	Secret string
+	// Tolerance is the maximum accepted timestamp skew. When zero, DefaultTolerance is used.
+	Tolerance time.Duration
+	// Verify customizes the signature scheme. Its Tolerance is used when Tolerance is zero.
+	Verify VerifyOptions
+	// MaxBodyBytes caps the request body size. When zero, DefaultMaxBodyBytes is used.
+	MaxBodyBytes int64
+	// VerifyFailureStatus is the status sent when the signature or timestamp is
+	// invalid. When zero, 401 is used. A 2xx status turns it into a soft failure
+	// that OnError still observes.
+	VerifyFailureStatus int
+	// OnError observes verification, decoding and callback failures.
+	OnError func(r *http.Request, err error)
+}
+
+// Router is an http.Handler that verifies, decodes and dispatches webhook events.
+//
+// Status codes:
+//   - 204 when the event was handled or has no registered callback
+//   - 400 when the body cannot be decoded
+//   - 401 when the signature or timestamp is invalid, unless VerifyFailureStatus is set
+//   - 405 for non-POST requests
+//   - 413 when the body exceeds MaxBodyBytes
+//   - 500 when a callback returns an error, so Rewrite redelivers the event
+type Router struct {
+	options    RouterOptions
+	mu         sync.RWMutex
+	handlers   map[api.WebhookEventType][]HandlerFunc
+	fallback   HandlerFunc
+	middleware []Middleware
+}
+
+// NewRouter creates a webhook router.
+func NewRouter(options RouterOptions) *Router {
+	return &Router{
+		options:  options,
+		handlers: make(map[api.WebhookEventType][]HandlerFunc),
+	}
+}
+
+// On registers a callback for an event type. Callbacks run in registration order.
+func (r *Router) On(eventType api.WebhookEventType, handler HandlerFunc) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+	return r
+}
+
+// Fallback registers a callback for events without a specific handler.
+func (r *Router) Fallback(handler HandlerFunc) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+	return r
+}
+
+// Use appends middleware. The first middleware added is the outermost.
+func (r *Router) Use(middleware ...Middleware) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middleware = append(r.middleware, middleware...)
+	return r
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.RLock()
+	var handler http.Handler = http.HandlerFunc(r.serve)
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+	r.mu.RUnlock()
+
+	handler.ServeHTTP(w, req)
+}
+
+// Dispatch runs the callbacks registered for event.
+func (r *Router) Dispatch(ctx context.Context, event Event) error {
+	r.mu.RLock()
+	handlers := r.handlers[event.EventType()]
+	if len(handlers) == 0 && r.fallback != nil {
+		handlers = []HandlerFunc{r.fallback}
+	}
+	r.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Router) serve(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		r.fail(w, req, http.StatusMethodNotAllowed, errors.New("Expected a POST request"))
+		return
+	}
+
+	limit := r.options.MaxBodyBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			r.fail(w, req, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		r.fail(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	verify := r.options.Verify
+	if r.options.Tolerance != 0 {
+		verify.Tolerance = r.options.Tolerance
+	}
+	err = VerifyWithOptions(payload, req.Header, r.options.Secret, verify)
+	if err != nil {
+		status := r.options.VerifyFailureStatus
+		if status == 0 {
+			status = http.StatusUnauthorized
+		}
+		r.fail(w, req, status, err)
+		return
+	}
+
+	event, err := ParseEvent(payload)
+	if err != nil {
+		r.fail(w, req, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := r.Dispatch(req.Context(), event); err != nil {
+		r.fail(w, req, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *Router) fail(w http.ResponseWriter, req *http.Request, status int, err error) {
+	if r.options.OnError != nil {
+		r.options.OnError(req, err)
+	}
+	if status < http.StatusBadRequest {
+		w.WriteHeader(status)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// Handle adapts a callback for a concrete event type into a HandlerFunc.
+// Events of any other type are ignored.
+//
+//	router.On(api.WebhookEventTypeSMSFailed, webhooks.Handle(func(ctx context.Context, e *webhooks.SMSFailedEvent) error {
+//		return nil
+//	}))
+func Handle[T Event](handler func(ctx context.Context, event T) error) HandlerFunc {
+	return func(ctx context.Context, event Event) error {
+		typed, ok := event.(T)
+		if !ok {
+			return nil
+		}
+		return handler(ctx, typed)
+	}
+}
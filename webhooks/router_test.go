@@ -0,0 +1,115 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+func newSignedRequest(t *testing.T, payload, secret string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/rewrite", strings.NewReader(payload))
+	for k, v := range Sign([]byte(payload), secret, time.Now()) {
+		req.Header[k] = v
+	}
+	return req
+}
+
+func TestRouterDispatchesTypedEvent(t *testing.T) {
+	var got *SMSFailedEvent
+	router := NewRouter(RouterOptions{Secret: "whsec"})
+	router.On(api.WebhookEventTypeSMSFailed, Handle(func(_ context.Context, e *SMSFailedEvent) error {
+		got = e
+		return nil
+	}))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newSignedRequest(t, testPayload, "whsec"))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d", rec.Code)
+	}
+	if got == nil || got.Data.ID != "9" {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+}
+
+func TestRouterStatusCodes(t *testing.T) {
+	router := NewRouter(RouterOptions{Secret: "whsec", MaxBodyBytes: 64})
+	router.On(api.WebhookEventTypeSMSFailed, func(context.Context, Event) error {
+		return errors.New("downstream unavailable")
+	})
+
+	cases := []struct {
+		name string
+		req  *http.Request
+		want int
+	}{
+		{"method", httptest.NewRequest(http.MethodGet, "/", nil), http.StatusMethodNotAllowed},
+		{"signature", newSignedRequest(t, `{"type":"sms.queued"}`, "other"), http.StatusUnauthorized},
+		{"too large", newSignedRequest(t, testPayload, "whsec"), http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, tc.req)
+		if rec.Code != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, rec.Code)
+		}
+	}
+
+	router.options.MaxBodyBytes = 0
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newSignedRequest(t, testPayload, "whsec"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected callback failure to return 500, got %d", rec.Code)
+	}
+}
+
+func TestRouterMiddlewareOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	router := NewRouter(RouterOptions{Secret: "whsec"}).Use(mark("outer"), mark("inner"))
+	router.ServeHTTP(httptest.NewRecorder(), newSignedRequest(t, testPayload, "whsec"))
+
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("unexpected middleware order: %v", order)
+	}
+}
+
+func TestRouterSoftVerifyFailure(t *testing.T) {
+	var observed error
+	called := false
+	router := NewRouter(RouterOptions{
+		Secret:              "whsec",
+		VerifyFailureStatus: http.StatusOK,
+		OnError:             func(_ *http.Request, err error) { observed = err },
+	})
+	router.Fallback(func(context.Context, Event) error {
+		called = true
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newSignedRequest(t, testPayload, "other"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the configured status, got %d", rec.Code)
+	}
+	if !errors.Is(observed, ErrInvalidSignature) || called {
+		t.Fatalf("expected OnError to see the failure without dispatching, got %v (dispatched %v)", observed, called)
+	}
+}
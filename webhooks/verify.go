@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The defaults below are assumptions; see the package docs.
+const (
+	// SignatureHeader is the default header carrying one or more "v1=<hex>" HMAC-SHA256 signatures.
+	SignatureHeader = "X-Rewrite-Signature"
+	// TimestampHeader is the default header carrying the delivery time as Unix seconds.
+	TimestampHeader = "X-Rewrite-Timestamp"
+	// SignatureVersion is the default version prefix of each signature.
+	SignatureVersion = "v1"
+	// DefaultTolerance is the maximum accepted age of a delivery.
+	DefaultTolerance = 5 * time.Minute
+)
+
+var (
+	// ErrMissingSignature is returned when the signature header is absent.
+	ErrMissingSignature = errors.New("Missing webhook signature header")
+	// ErrMissingTimestamp is returned when the timestamp header is absent or malformed.
+	ErrMissingTimestamp = errors.New("Missing or invalid webhook timestamp header")
+	// ErrInvalidSignature is returned when no signature matches the payload.
+	ErrInvalidSignature = errors.New("Invalid webhook signature")
+	// ErrTimestampExpired is returned when the delivery is outside the tolerance window.
+	ErrTimestampExpired = errors.New("Webhook timestamp is outside the tolerance window")
+)
+
+// VerifyOptions customizes signature verification.
+type VerifyOptions struct {
+	// Tolerance is the maximum accepted clock skew. When zero, DefaultTolerance is used.
+	// A negative value disables the timestamp check.
+	Tolerance time.Duration
+	// Now overrides the current time, mostly for tests.
+	Now func() time.Time
+	// SignatureHeader names the signature header. When empty, SignatureHeader is used.
+	SignatureHeader string
+	// TimestampHeader names the timestamp header. When empty, TimestampHeader is used.
+	TimestampHeader string
+	// SignatureVersion is the prefix before "=" in each signature. When empty,
+	// SignatureVersion is used.
+	SignatureVersion string
+	// SignedContent builds the bytes covered by the HMAC from the raw timestamp
+	// header and the payload. When nil, "<timestamp>.<payload>" is used.
+	SignedContent func(timestamp string, payload []byte) []byte
+}
+
+func (o VerifyOptions) withDefaults() VerifyOptions {
+	if o.SignatureHeader == "" {
+		o.SignatureHeader = SignatureHeader
+	}
+	if o.TimestampHeader == "" {
+		o.TimestampHeader = TimestampHeader
+	}
+	if o.SignatureVersion == "" {
+		o.SignatureVersion = SignatureVersion
+	}
+	if o.SignedContent == nil {
+		o.SignedContent = signedContent
+	}
+	return o
+}
+
+// Verify checks the delivery signature and rejects stale timestamps.
+func Verify(payload []byte, headers http.Header, secret string) error {
+	return VerifyWithOptions(payload, headers, secret, VerifyOptions{})
+}
+
+// VerifyWithOptions is Verify with a custom tolerance, clock or signature scheme.
+func VerifyWithOptions(payload []byte, headers http.Header, secret string, options VerifyOptions) error {
+	options = options.withDefaults()
+	header := headers.Get(options.SignatureHeader)
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	raw := strings.TrimSpace(headers.Get(options.TimestampHeader))
+	unix, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return ErrMissingTimestamp
+	}
+	timestamp := time.Unix(unix, 0)
+
+	tolerance := options.Tolerance
+	if tolerance == 0 {
+		tolerance = DefaultTolerance
+	}
+	if tolerance > 0 {
+		now := time.Now()
+		if options.Now != nil {
+			now = options.Now()
+		}
+		if now.Sub(timestamp).Abs() > tolerance {
+			return ErrTimestampExpired
+		}
+	}
+
+	expected := computeSignature(secret, options.SignedContent(raw, payload))
+	for _, part := range strings.Split(header, ",") {
+		version, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || version != options.SignatureVersion {
+			continue
+		}
+		decoded, err := hex.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// Sign returns headers that Verify accepts for payload. It is meant for testing
+// webhook handlers and follows the assumed default scheme, which may differ from
+// what Rewrite sends.
+func Sign(payload []byte, secret string, timestamp time.Time) http.Header {
+	return SignWithOptions(payload, secret, timestamp, VerifyOptions{})
+}
+
+// SignWithOptions is Sign for the signature scheme described by options.
+// Tolerance and Now are ignored.
+func SignWithOptions(payload []byte, secret string, timestamp time.Time, options VerifyOptions) http.Header {
+	options = options.withDefaults()
+	raw := strconv.FormatInt(timestamp.Unix(), 10)
+	headers := make(http.Header)
+	headers.Set(options.TimestampHeader, raw)
+	headers.Set(options.SignatureHeader, options.SignatureVersion+"="+hex.EncodeToString(computeSignature(secret, options.SignedContent(raw, payload))))
+	return headers
+}
+
+func signedContent(timestamp string, payload []byte) []byte {
+	content := make([]byte, 0, len(timestamp)+1+len(payload))
+	content = append(content, timestamp...)
+	content = append(content, '.')
+	return append(content, payload...)
+}
+
+func computeSignature(secret string, content []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(content)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,97 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rewritetoday/golang/api"
+)
+
+const testPayload = `{"id":"1","type":"sms.failed","project":"p1","createdAt":"2026-02-19T20:01:09.000Z","data":{"id":"9","to":"+5511999999999","status":"FAILED","error":{"code":"UNREACHABLE","message":"Handset unreachable"}}}`
+
+func TestVerifyAcceptsValidSignature(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	headers := Sign([]byte(testPayload), "whsec", now)
+
+	err := VerifyWithOptions([]byte(testPayload), headers, "whsec", VerifyOptions{Now: func() time.Time { return now }})
+	if err != nil {
+		t.Fatalf("unexpected verify error: %v", err)
+	}
+}
+
+func TestVerifyRejectsTamperedPayload(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	headers := Sign([]byte(testPayload), "whsec", now)
+
+	err := VerifyWithOptions([]byte(testPayload+" "), headers, "whsec", VerifyOptions{Now: func() time.Time { return now }})
+	if !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestVerifyRejectsStaleTimestamp(t *testing.T) {
+	sent := time.Unix(1_760_000_000, 0)
+	headers := Sign([]byte(testPayload), "whsec", sent)
+
+	err := VerifyWithOptions([]byte(testPayload), headers, "whsec", VerifyOptions{
+		Now: func() time.Time { return sent.Add(DefaultTolerance + time.Second) },
+	})
+	if !errors.Is(err, ErrTimestampExpired) {
+		t.Fatalf("expected ErrTimestampExpired, got %v", err)
+	}
+}
+
+func TestParseEventReturnsTypedEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(testPayload))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	failed, ok := event.(*SMSFailedEvent)
+	if !ok {
+		t.Fatalf("expected *SMSFailedEvent, got %T", event)
+	}
+	if failed.EventType() != api.WebhookEventTypeSMSFailed || failed.Data.ID != "9" || failed.Data.Error.Code != "UNREACHABLE" {
+		t.Fatalf("unexpected event: %+v", failed)
+	}
+}
+
+func TestParseEventUnknownType(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"id":"1","type":"sms.clicked","data":{"id":"9"}}`))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	if _, ok := event.(*UnknownEvent); !ok {
+		t.Fatalf("expected *UnknownEvent, got %T", event)
+	}
+}
+
+func TestVerifyWithCustomScheme(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	options := VerifyOptions{
+		Now:              func() time.Time { return now },
+		SignatureHeader:  "X-Signature",
+		TimestampHeader:  "X-Timestamp",
+		SignatureVersion: "sha256",
+		SignedContent: func(timestamp string, payload []byte) []byte {
+			return append([]byte(timestamp+":"), payload...)
+		},
+	}
+	headers := SignWithOptions([]byte(testPayload), "whsec", now, options)
+	if headers.Get("X-Signature") == "" || headers.Get(SignatureHeader) != "" {
+		t.Fatalf("expected the custom signature header, got %v", headers)
+	}
+
+	if err := VerifyWithOptions([]byte(testPayload), headers, "whsec", options); err != nil {
+		t.Fatalf("unexpected verify error: %v", err)
+	}
+	if err := VerifyWithOptions([]byte(testPayload), headers, "whsec", VerifyOptions{Now: options.Now}); !errors.Is(err, ErrMissingSignature) {
+		t.Fatalf("expected the default scheme to miss the custom header, got %v", err)
+	}
+
+	options.SignedContent = nil
+	if err := VerifyWithOptions([]byte(testPayload), headers, "whsec", options); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected a different signed content to fail, got %v", err)
+	}
+}